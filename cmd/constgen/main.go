@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+const codeTemplate = `// Code generated by constgen. DO NOT EDIT.
+
+package errors
+{{range .Formats}}
+// {{.Title}} codes
+var (
+{{- range .Codes}}
+	// {{.Name}} is {{.Code}} ({{.Path}}): {{.Description}}
+{{- if .Deprecated}}
+	//
+	// Deprecated: {{.Deprecated}}
+{{- end}}
+	{{.Name}} = {{.Literal}}
+{{- end}}
+)
+{{range .Codes}}
+// New{{.Name}} returns an error with code {{.Name}}
+{{- if .Deprecated}}
+//
+// Deprecated: {{.Deprecated}}
+{{- end}}
+func New{{.Name}}(message string) *Error {
+	return New({{.Name}}, message)
+}
+{{end}}{{end}}`
+
+type Code struct {
+	Name        string
+	Code        string
+	Path        string
+	Description string
+	Literal     string
+	Deprecated  string
+
+	lifecycle errors.Lifecycle
+}
+
+type FormatCodes struct {
+	Title string
+	Codes []Code
+}
+
+type CodeData struct {
+	Formats []FormatCodes
+}
+
+// errorsPkgPath is the import path of the package the file is generated
+// into; formats registered from other packages are skipped
+var errorsPkgPath = reflect.TypeOf(errors.Error{}).PkgPath()
+
+// getFormats returns the named codes of every registered format
+func getFormats() ([]FormatCodes, error) {
+	var formats []FormatCodes
+	seen := map[string]string{}
+
+	for _, f := range errors.Formats() {
+		if reflect.TypeOf(f.Prototype).PkgPath() != errorsPkgPath {
+			continue
+		}
+
+		perms := f.Prototype.GetPermutations()
+		sort.Slice(perms, func(i, j int) bool {
+			return perms[i].Code < perms[j].Code
+		})
+
+		fc := FormatCodes{Title: f.Prototype.GetDocSection().Title}
+		for _, p := range perms {
+			code, err := f.Decode(p.Code)
+			if err != nil {
+				return nil, err
+			}
+
+			name := naming.CodePrefix(f.Name) + naming.GoName(code.String())
+			if other, ok := seen[name]; ok {
+				return nil, fmt.Errorf("codes %s and %s both map to the name %s", other, p.Code, name)
+			}
+			seen[name] = p.Code
+
+			fc.Codes = append(fc.Codes, Code{
+				Name:        name,
+				Code:        p.Code,
+				Path:        code.String(),
+				Description: p.Fields["Description"],
+				Literal:     literal(code),
+				lifecycle:   p.Lifecycle,
+			})
+		}
+		formats = append(formats, fc)
+	}
+
+	// Deprecation notes refer to replacements by their generated name
+	names := map[string]string{}
+	for name, code := range seen {
+		names[code] = name
+	}
+	for _, fc := range formats {
+		for i := range fc.Codes {
+			fc.Codes[i].Deprecated = deprecationNote(fc.Codes[i].lifecycle, names)
+		}
+	}
+	return formats, nil
+}
+
+// deprecationNote returns the text of the Deprecated comment for a code,
+// or an empty string for active codes
+func deprecationNote(l errors.Lifecycle, names map[string]string) string {
+	if !l.Deprecated() {
+		return ""
+	}
+	note := "this code is " + string(l.State())
+	if l.DeprecatedSince != "" {
+		note += " since " + l.DeprecatedSince
+	}
+	if l.Replacement != "" {
+		replacement := l.Replacement
+		if name, ok := names[replacement]; ok {
+			replacement = name
+		}
+		note += ", use " + replacement + " instead"
+	}
+	return note + "."
+}
+
+// literal renders a code struct as a composite literal with named fields
+func literal(code errors.ErrorType) string {
+	v := reflect.ValueOf(code)
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		fields = append(fields, fmt.Sprintf("%s: %d", v.Type().Field(i).Name, v.Field(i).Interface()))
+	}
+	return fmt.Sprintf("%s{%s}", v.Type().Name(), strings.Join(fields, ", "))
+}
+
+// generate renders and formats the source of the generated file
+func generate(formats []FormatCodes) ([]byte, error) {
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("codes").Parse(codeTemplate))
+	if err := tmpl.Execute(&buf, CodeData{Formats: formats}); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	output := flag.String("o", "pkg/errors/codes_gen.go", "output file")
+	flag.Parse()
+
+	formats, err := getFormats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error collecting codes: %v\n", err)
+		os.Exit(1)
+	}
+
+	src, err := generate(formats)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating code: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing code: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Code constants generated successfully")
+}
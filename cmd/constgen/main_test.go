@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func TestDeprecationNote(t *testing.T) {
+	names := map[string]string{"E001": "TinyValidation"}
+	tests := []struct {
+		lifecycle errors.Lifecycle
+		want      string
+	}{
+		{errors.Lifecycle{}, ""},
+		{errors.Lifecycle{Status: errors.StatusActive}, ""},
+		{errors.Lifecycle{Status: errors.StatusDeprecated}, "this code is deprecated."},
+		{
+			errors.Lifecycle{Status: errors.StatusDeprecated, DeprecatedSince: "v2.0.0", Replacement: "E001"},
+			"this code is deprecated since v2.0.0, use TinyValidation instead.",
+		},
+		{
+			errors.Lifecycle{Status: errors.StatusRetired, Replacement: "E0ZZ"},
+			"this code is retired, use E0ZZ instead.",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := deprecationNote(tt.lifecycle, names); got != tt.want {
+			t.Errorf("deprecationNote(%+v) = %q; want %q", tt.lifecycle, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateUpToDate(t *testing.T) {
+	formats, err := getFormats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(formats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("../../pkg/errors/codes_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("pkg/errors/codes_gen.go is out of date, run go generate ./pkg/errors")
+	}
+}
+
+func TestGenerateDeprecated(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = []errors.TinyErrorInfo{
+			{Value: 0, Name: "unknown", Description: "Unknown error"},
+			{Value: 1, Name: "validation", Description: "Validation failed"},
+			{Value: 2, Name: "invalid", Description: "Invalid input", Lifecycle: errors.Lifecycle{
+				Status: errors.StatusDeprecated, DeprecatedSince: "v2.0.0", Replacement: "E001",
+			}},
+			{Value: 3, Name: "gone", Description: "Gone", Lifecycle: errors.Lifecycle{Status: errors.StatusRetired}},
+		}
+	})
+
+	formats, err := getFormats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(formats)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"\t// TinyInvalid is E002 (invalid): Invalid input\n\t//\n\t// Deprecated: this code is deprecated since v2.0.0, use TinyValidation instead.\n\tTinyInvalid = TinyCode{ErrType: 2}\n",
+		"// NewTinyInvalid returns an error with code TinyInvalid\n//\n// Deprecated: this code is deprecated since v2.0.0, use TinyValidation instead.\nfunc NewTinyInvalid(",
+		"\t// TinyGone is E003 (gone): Gone\n\t//\n\t// Deprecated: this code is retired.\n\tTinyGone = TinyCode{ErrType: 3}\n",
+		"\t// TinyValidation is E001 (validation): Validation failed\n\tTinyValidation = TinyCode{ErrType: 1}\n",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code is missing:\n%s", want)
+		}
+	}
+}
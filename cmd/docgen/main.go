@@ -2,18 +2,25 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
 	"text/template"
 
+	"github.com/thommeo/error-code-design/internal/coderef"
+	"github.com/thommeo/error-code-design/pkg/catalog"
 	"github.com/thommeo/error-code-design/pkg/errors"
 )
 
 const docTemplate = `# Error Codes Documentation
 
 This document is auto-generated. Do not edit manually.
+{{- if .Locale}}
+
+Descriptions and messages are shown in locale {{.Locale}} where translated.
+{{- end}}
 
 ## Table of Contents
 
@@ -50,23 +57,20 @@ type DocSection struct {
 }
 
 type DocData struct {
+	Locale   string // Empty for the default locale
 	Sections []DocSection
 }
 
-// getSections returns sections grouped by code type
-func getSections() []DocSection {
+// getSections returns sections grouped by code type with texts in the given
+// locale, listing the codes the lock retired after the catalog codes.
+// Formats with end-user messages get an extra Message column, and a
+// References column lists where codes are raised if refs is not nil.
+func getSections(lock *errors.Lock, locale string, refs map[string]coderef.Entry) []DocSection {
 	var sections []DocSection
 
-	// Get error types
-	errorTypes := []errors.ErrorType{
-		errors.TinyCode{},
-		errors.SimpleCode{},
-		errors.Simple511Code{},
-		errors.AppComponentErrorCode{},
-	}
-
-	// Process each error type
-	for _, et := range errorTypes {
+	// Process each registered format
+	for _, f := range errors.Formats() {
+		et := f.Prototype
 		docSection := et.GetDocSection()
 		perms := et.GetPermutations()
 
@@ -75,16 +79,61 @@ func getSections() []DocSection {
 			return perms[i].Code < perms[j].Code
 		})
 
+		hasMessages := false
+		for _, p := range perms {
+			if len(p.Localization.Messages) > 0 {
+				hasMessages = true
+			}
+		}
+		headers := docSection.Headers
+		if hasMessages {
+			headers = append(headers[:len(headers):len(headers)], "Message")
+		}
+		if refs != nil {
+			headers = append(headers[:len(headers):len(headers)], "References")
+		}
+
 		// Add rows
 		var rows [][]string
+		listed := map[string]bool{}
 		for _, p := range perms {
-			rows = append(rows, p.TableFields)
+			listed[p.Code] = true
+			// Copy before replacing the description column
+			row := append([]string(nil), p.TableFields...)
+			row[len(row)-1] = p.Description(locale)
+			if p.Lifecycle.Deprecated() {
+				row[len(row)-1] += fmt.Sprintf(" (%s)", p.Lifecycle)
+			}
+			if hasMessages {
+				message := ""
+				if len(p.Localization.Messages) > 0 {
+					message = p.Message(locale)
+				}
+				row = append(row, message)
+			}
+			if refs != nil {
+				row = append(row, refLocations(refs[p.Code].Refs))
+			}
+			rows = append(rows, row)
+		}
+		// Codes retired in the catalog are already listed
+		for _, e := range lock.Retired() {
+			if e.Format == f.Name && !listed[e.Code] {
+				row := []string{e.Code, e.Path, "retired: " + e.Description}
+				if hasMessages {
+					row = append(row, "")
+				}
+				if refs != nil {
+					row = append(row, "")
+				}
+				rows = append(rows, row)
+			}
 		}
 
 		sections = append(sections, DocSection{
 			Title:       docSection.Title,
 			Description: docSection.Description,
-			Headers:     docSection.Headers,
+			Headers:     headers,
 			Rows:        rows,
 		})
 	}
@@ -92,6 +141,18 @@ func getSections() []DocSection {
 	return sections
 }
 
+// refLocations lists reference locations in a table cell
+func refLocations(refs []coderef.Ref) string {
+	if len(refs) == 0 {
+		return "none"
+	}
+	locations := make([]string, len(refs))
+	for i, ref := range refs {
+		locations[i] = fmt.Sprintf("`%s:%d`", ref.File, ref.Line)
+	}
+	return strings.Join(locations, "<br>")
+}
+
 // Custom template function to convert section titles to anchor IDs
 func anchorID(title string) string {
 	// Simple conversion: lowercase and replace spaces with hyphens
@@ -108,8 +169,54 @@ func anchorID(title string) string {
 	return strings.ToLower(result)
 }
 
+// docPath returns the documentation file of a locale
+func docPath(locale string) string {
+	if locale == "" {
+		return "docs/error-codes.md"
+	}
+	return "docs/error-codes." + locale + ".md"
+}
+
 func main() {
-	var buf bytes.Buffer
+	catalogPath := flag.String("catalog", "", "YAML or JSON catalog file replacing the built-in code trees")
+	lockPath := flag.String("lock", "errcodes.lock", "allocation lock file, used if it exists")
+	refsPath := flag.String("refs", "", "JSON file written by errcode refs -json, adds the source locations of each code")
+	flag.Parse()
+
+	lock, err := errors.LoadLock(*lockPath)
+	if os.IsNotExist(err) {
+		lock = &errors.Lock{}
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading lock: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *catalogPath != "" {
+		c, err := catalog.Load(*catalogPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+			os.Exit(1)
+		}
+		if err := c.InstallLocked(lock); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else if problems := lock.Check(errors.TakeSnapshot()); len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, p)
+		}
+		fmt.Fprintln(os.Stderr, "Error: catalog conflicts with the lock")
+		os.Exit(1)
+	}
+
+	var refs map[string]coderef.Entry
+	if *refsPath != "" {
+		refs, err = coderef.LoadEntries(*refsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading references: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// Create template with custom function
 	tmpl := template.New("doc").Funcs(template.FuncMap{
@@ -119,18 +226,24 @@ func main() {
 	// Parse template
 	tmpl = template.Must(tmpl.Parse(docTemplate))
 
-	data := DocData{
-		Sections: getSections(),
-	}
+	// One document for the default locale and one per translated locale
+	locales := append([]string{""}, errors.Locales()...)
+	for _, locale := range locales {
+		var buf bytes.Buffer
+		data := DocData{
+			Locale:   locale,
+			Sections: getSections(lock, locale, refs),
+		}
 
-	if err := tmpl.Execute(&buf, data); err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating documentation: %v\n", err)
-		os.Exit(1)
-	}
+		if err := tmpl.Execute(&buf, data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating documentation: %v\n", err)
+			os.Exit(1)
+		}
 
-	if err := os.WriteFile("docs/error-codes.md", buf.Bytes(), 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing documentation: %v\n", err)
-		os.Exit(1)
+		if err := os.WriteFile(docPath(locale), buf.Bytes(), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing documentation: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	fmt.Println("Documentation generated successfully")
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thommeo/error-code-design/internal/coderef"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// tinySection returns the section of the tiny format
+func tinySection(t *testing.T, sections []DocSection) DocSection {
+	t.Helper()
+	for _, s := range sections {
+		if s.Title == "Tiny Format" {
+			return s
+		}
+	}
+	t.Fatal("no Tiny Format section")
+	return DocSection{}
+}
+
+func TestGetSectionsLifecycle(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = []errors.TinyErrorInfo{
+			{Value: 0, Name: "unknown", Description: "Unknown error"},
+			{Value: 1, Name: "validation", Description: "Validation failed"},
+			{Value: 2, Name: "invalid", Description: "Invalid input", Lifecycle: errors.Lifecycle{
+				Status: errors.StatusDeprecated, DeprecatedSince: "v2.0.0", Replacement: "E001",
+			}},
+			{Value: 3, Name: "gone", Description: "Gone", Lifecycle: errors.Lifecycle{Status: errors.StatusRetired}},
+		}
+	})
+	lock := &errors.Lock{}
+	lock.Update(errors.TakeSnapshot(), "v1.0.0")
+	lock.Entries = append(lock.Entries, errors.LockEntry{
+		Code: "E005", Format: "tiny", Path: "removed", Description: "Removed code", FirstSeen: "v1.0.0", Retired: true,
+	})
+
+	section := tinySection(t, getSections(lock, "", nil))
+	if want := []string{"Code", "Type", "Description"}; !reflect.DeepEqual(section.Headers, want) {
+		t.Errorf("Headers = %v; want %v", section.Headers, want)
+	}
+	want := [][]string{
+		{"E000", "unknown", "Unknown error"},
+		{"E001", "validation", "Validation failed"},
+		{"E002", "invalid", "Invalid input (deprecated since v2.0.0, use E001)"},
+		{"E003", "gone", "Gone (retired)"},
+		{"E005", "removed", "retired: Removed code"},
+	}
+	if !reflect.DeepEqual(section.Rows, want) {
+		t.Errorf("Rows = %q; want %q", section.Rows, want)
+	}
+}
+
+func TestGetSectionsRefs(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = []errors.TinyErrorInfo{
+			{Value: 0, Name: "unknown", Description: "Unknown error"},
+			{Value: 1, Name: "validation", Description: "Validation failed"},
+		}
+	})
+	lock := &errors.Lock{Entries: []errors.LockEntry{
+		{Code: "E002", Format: "tiny", Path: "removed", Description: "Removed code", FirstSeen: "v1.0.0", Retired: true},
+	}}
+	refs := map[string]coderef.Entry{
+		"E001": {Code: "E001", Refs: []coderef.Ref{
+			{Code: "E001", File: "api/users.go", Line: 12},
+			{Code: "E001", File: "api/orders.go", Line: 40},
+		}},
+	}
+
+	section := tinySection(t, getSections(lock, "", refs))
+	if want := []string{"Code", "Type", "Description", "References"}; !reflect.DeepEqual(section.Headers, want) {
+		t.Errorf("Headers = %v; want %v", section.Headers, want)
+	}
+	want := [][]string{
+		{"E000", "unknown", "Unknown error", "none"},
+		{"E001", "validation", "Validation failed", "`api/users.go:12`<br>`api/orders.go:40`"},
+		{"E002", "removed", "retired: Removed code", ""},
+	}
+	if !reflect.DeepEqual(section.Rows, want) {
+		t.Errorf("Rows = %q; want %q", section.Rows, want)
+	}
+}
+
+func TestGetSectionsLocale(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = []errors.TinyErrorInfo{
+			{Value: 0, Name: "unknown", Description: "Unknown error"},
+			{Value: 1, Name: "validation", Description: "Validation failed", Localization: errors.Localization{
+				Descriptions: map[string]string{"de": "Validierung fehlgeschlagen"},
+				Messages: map[string]string{
+					"en": "Some of the entered data is invalid.",
+					"de": "Einige der eingegebenen Daten sind ungültig.",
+				},
+			}},
+			{Value: 2, Name: "not_found", Description: "Resource not found", Localization: errors.Localization{
+				Messages: map[string]string{"en": "The resource does not exist."},
+			}},
+		}
+	})
+	if got := errors.Locales(); !reflect.DeepEqual(got, []string{"de"}) {
+		t.Errorf("Locales() = %v; want [de]", got)
+	}
+
+	section := tinySection(t, getSections(&errors.Lock{}, "de", nil))
+	if want := []string{"Code", "Type", "Description", "Message"}; !reflect.DeepEqual(section.Headers, want) {
+		t.Errorf("Headers = %v; want %v", section.Headers, want)
+	}
+	// Untranslated texts fall back to English
+	want := [][]string{
+		{"E000", "unknown", "Unknown error", ""},
+		{"E001", "validation", "Validierung fehlgeschlagen", "Einige der eingegebenen Daten sind ungültig."},
+		{"E002", "not_found", "Resource not found", "The resource does not exist."},
+	}
+	if !reflect.DeepEqual(section.Rows, want) {
+		t.Errorf("Rows = %q; want %q", section.Rows, want)
+	}
+}
+
+func TestAnchorID(t *testing.T) {
+	if got := anchorID("App Component Format"); got != "app-component-format" {
+		t.Errorf("anchorID() = %s; want app-component-format", got)
+	}
+}
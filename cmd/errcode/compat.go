@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func runSnapshot(args []string) int {
+	fs, catalogPath := newFlagSet("snapshot")
+	output := fs.String("o", "", "output file (default stdout)")
+	fs.Parse(args)
+
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating snapshot: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := errors.TakeSnapshot().Write(out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing snapshot: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func runCompat(args []string) int {
+	fs, catalogPath := newFlagSet("compat")
+	snapshotPath := fs.String("snapshot", "", "snapshot of the previous catalog version (required)")
+	fs.Parse(args)
+
+	if *snapshotPath == "" {
+		fmt.Fprintln(os.Stderr, "compat: -snapshot is required")
+		return 2
+	}
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+
+	f, err := os.Open(*snapshotPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening snapshot: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	old, err := errors.ReadSnapshot(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	breaking := 0
+	for _, c := range errors.Compare(old, errors.TakeSnapshot()) {
+		if c.Kind.Breaking() {
+			breaking++
+			fmt.Printf("BREAKING %s\n", c)
+		} else {
+			fmt.Printf("         %s\n", c)
+		}
+	}
+	if breaking > 0 {
+		fmt.Fprintf(os.Stderr, "%d breaking change(s) found\n", breaking)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func runLint(args []string) int {
+	fs, catalogPath := newFlagSet("lint")
+	lockPath := fs.String("lock", defaultLockPath, "allocation lock file, checked if it exists")
+	fs.Parse(args)
+
+	lock, err := loadCatalog(*catalogPath, *lockPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+
+	problems := append(errors.Validate(), lock.Check(errors.TakeSnapshot())...)
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "%d catalog problem(s) found\n", len(problems))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+const defaultLockPath = "errcodes.lock"
+
+// loadLock reads the lock file, returning an empty lock if it does not
+// exist yet
+func loadLock(path string) (*errors.Lock, error) {
+	lock, err := errors.LoadLock(path)
+	if os.IsNotExist(err) {
+		return &errors.Lock{}, nil
+	}
+	return lock, err
+}
+
+func runLock(args []string) int {
+	fs, catalogPath := newFlagSet("lock")
+	lockPath := fs.String("lock", defaultLockPath, "allocation lock file")
+	version := fs.String("version", "", "version recorded as first seen for new codes (required)")
+	fs.Parse(args)
+
+	if *version == "" {
+		fmt.Fprintln(os.Stderr, "lock: -version is required")
+		return 2
+	}
+	lock, err := loadCatalog(*catalogPath, *lockPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+
+	snapshot := errors.TakeSnapshot()
+	if problems := lock.Check(snapshot); len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Println(p)
+		}
+		fmt.Fprintf(os.Stderr, "%d code(s) conflict with the lock, lock not updated\n", len(problems))
+		return 1
+	}
+	lock.Update(snapshot, *version)
+
+	f, err := os.Create(*lockPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing lock: %v\n", err)
+		return 1
+	}
+	err = lock.Write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing lock: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/thommeo/error-code-design/pkg/catalog"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// command is an errcode subcommand. run returns the process exit code.
+type command struct {
+	usage string
+	run   func(args []string) int
+}
+
+var commands = map[string]command{
+	"lint":     {"lint [-catalog file] [-lock file]: report catalog problems, exit 1 if any", runLint},
+	"lock":     {"lock -version v [-catalog file] [-lock file]: record allocated and retired codes in the lock file", runLock},
+	"snapshot": {"snapshot [-catalog file] [-o file]: write the codes of the catalog as JSON", runSnapshot},
+	"compat":   {"compat -snapshot file [-catalog file]: compare the catalog to a snapshot, exit 1 on breaking changes", runCompat},
+	"decode":   {"decode [-catalog file] [-lock file] [-json] [-i] [code...]: explain codes, read from stdin if none are given", runDecode},
+	"lookup":   {"lookup [-catalog file] [-format name] [-json] [path...]: find the codes of dotted paths, read from stdin if none are given", runLookup},
+	"list":     {"list [-catalog file] [-format name] [-prefix path] [-json]: list the codes of the catalog", runList},
+	"stats":    {"stats [-catalog file] [-field name] [-time-field name] [-bucket d] [-top n] [-format table|csv|json] [-all] [file...]: count code occurrences in logs, read from stdin if no files are given", runStats},
+	"refs":     {"refs [-catalog file] [-dir dir] [-format name] [-tests] [-unused] [-json] [package...]: report where codes are referenced in Go source, or with -unused the catalog codes nobody references", runRefs},
+	"scan":     {"scan [-catalog file] [-json] [-all] [-matching] [file...]: annotate the codes found in text, read from stdin if no files are given", runScan},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: errcode <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+// newFlagSet returns a flag set for a subcommand with the shared -catalog
+// flag registered
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	catalogPath := fs.String("catalog", "", "YAML or JSON catalog file replacing the built-in code trees")
+	return fs, catalogPath
+}
+
+// loadCatalog installs the catalog file if one was given and returns the
+// allocation lock at lockPath. A catalog that conflicts with the lock is not
+// installed.
+func loadCatalog(path, lockPath string) (*errors.Lock, error) {
+	lock, err := loadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return lock, nil
+	}
+	c, err := catalog.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.InstallLocked(lock); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	os.Exit(cmd.run(os.Args[2:]))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	stderrors "errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs the command instead of the tests if the test binary is
+// started by errcode, so that exit codes can be checked
+func TestMain(m *testing.M) {
+	if os.Getenv("ERRCODE_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// errcode runs the command with args and stdin, returning its output and
+// exit code
+func errcode(t *testing.T, stdin string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "ERRCODE_RUN_MAIN=1")
+	cmd.Stdin = strings.NewReader(stdin)
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if stderrors.As(err, &exitErr) {
+		code = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return out.String(), errOut.String(), code
+}
+
+func TestCommands(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "errcodes.lock")
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string // Substring of the output, if set
+		stderr string // Substring of the error output, if set
+	}{
+		{"no command", nil, "", 2, "", "Usage: errcode"},
+		{"unknown command", []string{"bogus"}, "", 2, "", `Unknown command "bogus"`},
+		{"unknown flag", []string{"decode", "-bogus"}, "", 2, "", "flag provided but not defined"},
+
+		{"decode", []string{"decode", "E10075"}, "", 0, "api.validation_error", ""},
+		{"decode lower case", []string{"decode", "e10075"}, "", 1, "", `decode "e10075": invalid character`},
+		{"decode ignoring case", []string{"decode", "-i", "e10075"}, "", 0, "api.validation_error", ""},
+		{"decode not in catalog", []string{"decode", "E10ZZZ"}, "", 1, "not in catalog", ""},
+		{"decode stdin", []string{"decode"}, "E002 hello", 1, "not_found", `decode "hello"`},
+		{"decode JSON", []string{"decode", "-json", "E002"}, "", 0, `"path": "not_found"`, ""},
+		{"decode JSON fields", []string{"decode", "-json", "EA0MTXD"}, "", 0, `"name": "sub_component"`, ""},
+		{"decode fields", []string{"decode", "EA0MTXD"}, "", 0, "sub_component:", ""},
+
+		{"lookup", []string{"lookup", "api.validation_error"}, "", 0, "E10075", ""},
+		{"lookup unknown segment", []string{"lookup", "api.nope"}, "", 1, "", `unknown error type "nope" in api`},
+		{"lookup segment count", []string{"lookup", "backend.handler.users"}, "", 1, "", "no format has names of 3 segments"},
+		{"lookup unknown format", []string{"lookup", "-format", "bogus", "x"}, "", 2, "", `unknown format "bogus"`},
+		{"lookup stdin", []string{"lookup", "-format", "simple511"}, "http.not_found", 0, "E301L0", ""},
+		{"lookup ambiguous", []string{"lookup", "-catalog", "testdata/ambiguous.yaml", "api.validation_error"}, "", 0, "E3035T", ""},
+		{"lookup qualified", []string{"lookup", "-catalog", "testdata/ambiguous.yaml", "-format", "simple", "api.validation_error"}, "", 0, "E10075", ""},
+
+		{"list", []string{"list", "-format", "tiny"}, "", 0, "E0ZZ  tiny  max", ""},
+		{"list prefix", []string{"list", "-prefix", "http"}, "", 0, "E301L0  simple511  http.not_found", ""},
+		{"list unknown format", []string{"list", "-format", "bogus"}, "", 2, "", `unknown format "bogus"`},
+
+		{"lint", []string{"lint"}, "", 0, "", ""},
+		{"lint problems", []string{"lint", "-catalog", "testdata/duplicate.yaml"}, "", 1, "duplicate error type value 1", "1 catalog problem(s) found"},
+		{"lint lock", []string{"lint", "-lock", "testdata/renamed.lock"}, "", 1, "changes path of code E001", ""},
+		{"lint catalog conflicts with lock", []string{"lint", "-catalog", "../../pkg/catalog/errcodes.yaml", "-lock", "testdata/renamed.lock"}, "", 1, "", "catalog conflicts with lock"},
+		{"decode catalog conflicts with lock", []string{"decode", "-catalog", "../../pkg/catalog/errcodes.yaml", "-lock", "testdata/renamed.lock", "E001"}, "", 1, "", "changes path of code E001"},
+		{"lint invalid catalog", []string{"lint", "-catalog", "testdata/missing.yaml"}, "", 1, "", "Error loading catalog"},
+
+		{"lock without version", []string{"lock"}, "", 2, "", "-version is required"},
+		{"lock", []string{"lock", "-version", "v1.0.0", "-lock", lockPath}, "", 0, "", ""},
+		{"lock conflict", []string{"lock", "-version", "v1.1.0", "-lock", "testdata/renamed.lock"}, "", 1, "changes path of code E001", "lock not updated"},
+
+		{"scan", []string{"scan"}, "E002 hello\nplain\n", 0, "E002[not_found] hello\nplain\n", ""},
+		{"scan matching", []string{"scan", "-matching"}, "plain\nfailed: E10075\n", 0, "failed: E10075[api.validation_error]\n", ""},
+		{"scan missing file", []string{"scan", "testdata/missing.log"}, "", 1, "", "Error:"},
+
+		{"stats", []string{"stats"}, "E002 x\nE002 y\nE001 z\n", 0, "3 occurrence(s) of 2 code(s)", ""},
+		{"stats unknown output format", []string{"stats", "-format", "xml"}, "", 2, "", `unknown output format "xml"`},
+
+		{"refs", []string{"refs", "-dir", "testdata/refs", "-format", "tiny"}, "", 0, "E002 not_found: 1 reference(s)\n\trefs.go:9:27\tname\n", ""},
+		{"refs unused", []string{"refs", "-dir", "testdata/refs", "-format", "tiny", "-unused"}, "", 1, "E001\ttiny\tvalidation\n", "4 catalog code(s) without references"},
+		{"refs unknown format", []string{"refs", "-format", "bogus"}, "", 2, "", `unknown format "bogus"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := errcode(t, tt.stdin, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code = %d; want %d\nstdout:\n%s\nstderr:\n%s", code, tt.code, stdout, stderr)
+			}
+			if !strings.Contains(stdout, tt.stdout) {
+				t.Errorf("stdout = %q; want it to contain %q", stdout, tt.stdout)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("stderr = %q; want it to contain %q", stderr, tt.stderr)
+			}
+		})
+	}
+
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Errorf("lock did not write the lock file: %v", err)
+	}
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// codeInfo is the description of a code printed by decode, lookup and list
+type codeInfo struct {
+	Code        string       `json:"code"`
+	Format      string       `json:"format"`
+	Path        string       `json:"path,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Fields      []fieldValue `json:"fields"`
+	Status      string       `json:"status,omitempty"`
+	Known       bool         `json:"known"`
+}
+
+// fieldValue is a decoded layout field with the name of its catalog entry
+type fieldValue struct {
+	Name  string `json:"name"`
+	Value uint32 `json:"value"`
+	Label string `json:"label,omitempty"`
+}
+
+// describe returns the description of a code of a format. Codes missing
+// from the catalog are described from the lock if it retired them.
+func describe(f errors.Format, code errors.ErrorType, lock *errors.Lock) (codeInfo, error) {
+	encoded, err := errors.EncodeChecked(code)
+	if err != nil {
+		return codeInfo{}, err
+	}
+	info := codeInfo{Code: encoded, Format: f.Name}
+
+	p, known := errors.Lookup(code)
+	if known {
+		info.Known = true
+		info.Path = code.String()
+		info.Description = p.Fields["Description"]
+		if p.Lifecycle.Deprecated() {
+			info.Status = p.Lifecycle.String()
+		}
+	} else if e, ok := lock.Lookup(encoded); ok && e.Retired {
+		info.Path = e.Path
+		info.Description = e.Description
+		info.Status = "retired"
+	}
+
+	if f.Layout != nil {
+		values, err := f.Layout.Decode(encoded)
+		if err != nil {
+			return codeInfo{}, err
+		}
+		for i, field := range f.Layout.Fields {
+			info.Fields = append(info.Fields, fieldValue{
+				Name:  naming.FieldKey(field.Name),
+				Value: values[i],
+				Label: p.Fields[field.Name],
+			})
+		}
+	}
+	return info, nil
+}
+
+// catalogCodes returns the descriptions of all catalog codes of the named
+// format, or of all formats if format is empty, sorted by code
+func catalogCodes(format string) ([]codeInfo, error) {
+	var infos []codeInfo
+	for _, f := range errors.Formats() {
+		if format != "" && f.Name != format {
+			continue
+		}
+		for _, p := range f.Prototype.GetPermutations() {
+			code, err := f.Decode(p.Code)
+			if err != nil {
+				return nil, err
+			}
+			info, err := describe(f, code, &errors.Lock{})
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+	return infos, nil
+}
+
+// checkFormat reports an error if format is set but not registered
+func checkFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if _, ok := errors.LookupFormatName(format); !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return nil
+}
+
+// queryArgs returns the arguments, or the words read from stdin if there
+// are none
+func queryArgs(args []string, stdin io.Reader) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var words []string
+	scanner := bufio.NewScanner(stdin)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	return words, scanner.Err()
+}
+
+// printInfos writes the descriptions as a JSON array or as text blocks
+func printInfos(w io.Writer, infos []codeInfo, asJSON bool) error {
+	if asJSON {
+		if infos == nil {
+			infos = []codeInfo{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+
+	for i, info := range infos {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "%s\n", info.Code)
+		fmt.Fprintf(tw, "  format:\t%s\n", info.Format)
+		if info.Path != "" {
+			fmt.Fprintf(tw, "  path:\t%s\n", info.Path)
+		}
+		if info.Description != "" {
+			fmt.Fprintf(tw, "  description:\t%s\n", info.Description)
+		}
+		if info.Status != "" {
+			fmt.Fprintf(tw, "  status:\t%s\n", info.Status)
+		}
+		if !info.Known && info.Status == "" {
+			fmt.Fprintf(tw, "  status:\tnot in catalog\n")
+		}
+		for _, field := range info.Fields {
+			fmt.Fprintf(tw, "  %s:\t%d\t%s\n", field.Name, field.Value, field.Label)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runDecode(args []string) int {
+	fs, catalogPath := newFlagSet("decode")
+	lockPath := fs.String("lock", defaultLockPath, "allocation lock file, used to explain retired codes")
+	asJSON := fs.Bool("json", false, "print JSON")
+	ignoreCase := fs.Bool("i", false, "accept codes in lower or mixed case")
+	fs.Parse(args)
+
+	lock, err := loadCatalog(*catalogPath, *lockPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+	codes, err := queryArgs(fs.Args(), os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading codes: %v\n", err)
+		return 1
+	}
+
+	status := 0
+	var infos []codeInfo
+	for _, s := range codes {
+		if *ignoreCase {
+			s = strings.ToUpper(s)
+		}
+		code, err := errors.Decode(s)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", s, err)
+			status = 1
+			continue
+		}
+		f, _ := errors.LookupFormat(code.GetType())
+		info, err := describe(f, code, lock)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", s, err)
+			status = 1
+			continue
+		}
+		if !info.Known {
+			status = 1
+		}
+		infos = append(infos, info)
+	}
+
+	if err := printInfos(os.Stdout, infos, *asJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return status
+}
+
+// lookupPath returns the catalog code with a dotted path, qualified with
+// format if it is set. A path matching codes of several formats returns
+// all of them.
+func lookupPath(format, path string) ([]errors.ErrorType, error) {
+	query := path
+	if format != "" {
+		query = format + ":" + path
+	}
+	code, _, err := errors.ParseName(query)
+	var nameErr *errors.NameError
+	if stderrors.Is(err, errors.ErrAmbiguousName) && stderrors.As(err, &nameErr) {
+		var codes []errors.ErrorType
+		for _, name := range nameErr.Expected {
+			code, _, err := errors.ParseName(name + ":" + path)
+			if err != nil {
+				return nil, err
+			}
+			codes = append(codes, code)
+		}
+		return codes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []errors.ErrorType{code}, nil
+}
+
+func runLookup(args []string) int {
+	fs, catalogPath := newFlagSet("lookup")
+	format := fs.String("format", "", "only look up codes of this format")
+	asJSON := fs.Bool("json", false, "print JSON")
+	fs.Parse(args)
+
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+	if err := checkFormat(*format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	paths, err := queryArgs(fs.Args(), os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading paths: %v\n", err)
+		return 1
+	}
+
+	status := 0
+	var infos []codeInfo
+	for _, path := range paths {
+		codes, err := lookupPath(*format, path)
+		if err != nil {
+			// The error explains which segment did not match
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+		for _, code := range codes {
+			f, _ := errors.LookupFormat(code.GetType())
+			info, err := describe(f, code, &errors.Lock{})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+				status = 1
+				continue
+			}
+			infos = append(infos, info)
+		}
+	}
+
+	if err := printInfos(os.Stdout, infos, *asJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return status
+}
+
+func runList(args []string) int {
+	fs, catalogPath := newFlagSet("list")
+	format := fs.String("format", "", "only list codes of this format")
+	prefix := fs.String("prefix", "", "only list codes whose path starts with these dotted segments")
+	asJSON := fs.Bool("json", false, "print JSON")
+	fs.Parse(args)
+
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+	if err := checkFormat(*format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	all, err := catalogCodes(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	var infos []codeInfo
+	for _, info := range all {
+		if *prefix == "" || info.Path == *prefix || strings.HasPrefix(info.Path, *prefix+".") {
+			infos = append(infos, info)
+		}
+	}
+
+	if *asJSON {
+		if err := printInfos(os.Stdout, infos, true); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Code, info.Format, info.Path, info.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/thommeo/error-code-design/internal/coderef"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// findRefs loads the packages matching patterns in dir and returns the
+// references to codes outside generated files, with file names relative to
+// dir
+func findRefs(dir string, patterns []string, tests bool) ([]coderef.Ref, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Dependencies are type-checked from source, which does not depend on
+	// the export data format of the installed toolchain
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: tests,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("packages contain errors")
+	}
+
+	names := coderef.Names()
+	var refs []coderef.Ref
+	// Test variants of a package repeat its files
+	seen := map[coderef.Ref]bool{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			if ast.IsGenerated(file) {
+				continue
+			}
+			for _, ref := range coderef.Collect(pkg.Fset, file, pkg.TypesInfo, names) {
+				if rel, err := filepath.Rel(dir, ref.File); err == nil {
+					ref.File = filepath.ToSlash(rel)
+				}
+				if !seen[ref] {
+					seen[ref] = true
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return refs, nil
+}
+
+// refEntries groups references by code. Every catalog code of the format
+// gets an entry, followed by the referenced codes missing from the catalog.
+func refEntries(format string, refs []coderef.Ref) ([]coderef.Entry, error) {
+	infos, err := catalogCodes(format)
+	if err != nil {
+		return nil, err
+	}
+	var entries []coderef.Entry
+	index := map[string]int{}
+	for _, info := range infos {
+		index[info.Code] = len(entries)
+		entries = append(entries, coderef.Entry{Code: info.Code, Format: info.Format, Path: info.Path, Refs: []coderef.Ref{}})
+	}
+	unknown := len(entries)
+	for _, ref := range refs {
+		i, ok := index[ref.Code]
+		if !ok {
+			code, err := errors.Decode(ref.Code)
+			if err != nil {
+				continue
+			}
+			f, _ := errors.LookupFormat(code.GetType())
+			if format != "" && f.Name != format {
+				continue
+			}
+			i = len(entries)
+			index[ref.Code] = i
+			entries = append(entries, coderef.Entry{Code: ref.Code, Format: f.Name})
+		}
+		entries[i].Refs = append(entries[i].Refs, ref)
+	}
+	sort.Slice(entries[unknown:], func(i, j int) bool {
+		return entries[unknown+i].Code < entries[unknown+j].Code
+	})
+	return entries, nil
+}
+
+func runRefs(args []string) int {
+	fs, catalogPath := newFlagSet("refs")
+	dir := fs.String("dir", ".", "directory of the module to load the packages from")
+	format := fs.String("format", "", "only report codes of this format")
+	tests := fs.Bool("tests", false, "also count references in test files")
+	unused := fs.Bool("unused", false, "only list catalog codes without references, exit 1 if there are any")
+	asJSON := fs.Bool("json", false, "print the codes and their references as JSON, as read by docgen -refs")
+	fs.Parse(args)
+
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+	if err := checkFormat(*format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	refs, err := findRefs(*dir, patterns, *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading packages: %v\n", err)
+		return 1
+	}
+	entries, err := refEntries(*format, refs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *unused {
+		var dead []coderef.Entry
+		for _, e := range entries {
+			if e.Path != "" && len(e.Refs) == 0 {
+				dead = append(dead, e)
+			}
+		}
+		if *asJSON {
+			if dead == nil {
+				dead = []coderef.Entry{}
+			}
+			if err := writeJSON(dead); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+		} else {
+			for _, e := range dead {
+				fmt.Printf("%s\t%s\t%s\n", e.Code, e.Format, e.Path)
+			}
+		}
+		if len(dead) > 0 {
+			fmt.Fprintf(os.Stderr, "%d catalog code(s) without references\n", len(dead))
+			return 1
+		}
+		return 0
+	}
+
+	if *asJSON {
+		if err := writeJSON(entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	for _, e := range entries {
+		path := e.Path
+		if path == "" {
+			path = "(not in catalog)"
+		}
+		fmt.Printf("%s %s: %d reference(s)\n", e.Code, path, len(e.Refs))
+		for _, ref := range e.Refs {
+			fmt.Printf("\t%s\t%s\n", ref, ref.Kind)
+		}
+	}
+	return 0
+}
+
+// writeJSON prints v as indented JSON
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/thommeo/error-code-design/pkg/errors/errscan"
+)
+
+// scanRecord is a JSON line written by scan -json
+type scanRecord struct {
+	File string `json:"file,omitempty"`
+	errscan.Match
+}
+
+// inputs calls fn with each named file, or with stdin if there are none
+func inputs(files []string, fn func(name string, r io.Reader) error) error {
+	if len(files) == 0 {
+		return fn("", os.Stdin)
+	}
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = fn(name, f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func runScan(args []string) int {
+	fs, catalogPath := newFlagSet("scan")
+	asJSON := fs.Bool("json", false, "print one JSON record per code instead of annotated lines")
+	all := fs.Bool("all", false, "also report codes missing from the catalog")
+	matching := fs.Bool("matching", false, "only print lines containing codes")
+	fs.Parse(args)
+
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(out)
+	err := inputs(fs.Args(), func(name string, r io.Reader) error {
+		s := errscan.NewScanner(r)
+		s.IncludeUnknown = *all
+		for s.Scan() {
+			matches := s.Matches()
+			switch {
+			case *asJSON:
+				for _, m := range matches {
+					if err := enc.Encode(scanRecord{File: name, Match: m}); err != nil {
+						return err
+					}
+				}
+			case len(matches) > 0 || !*matching:
+				if _, err := out.Write(errscan.Annotate(s.Bytes(), matches)); err != nil {
+					return err
+				}
+			}
+		}
+		return s.Err()
+	})
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
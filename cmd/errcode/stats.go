@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+	"github.com/thommeo/error-code-design/pkg/errors/errscan"
+)
+
+// timeLayouts are the timestamp formats recognized at the start of plain
+// text lines
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006/01/02 15:04:05"}
+
+// lineTime parses the timestamp at the start of a plain text line
+func lineTime(line []byte) time.Time {
+	for _, layout := range timeLayouts {
+		// Try the prefix spanning as many space separated words as the layout
+		n := strings.Count(layout, " ") + 1
+		fields := bytes.SplitN(line, []byte(" "), n+1)
+		if len(fields) < n {
+			continue
+		}
+		prefix := string(bytes.TrimSpace(bytes.Join(fields[:n], []byte(" "))))
+		if t, err := time.Parse(layout, prefix); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// jsonField returns the value at a dotted path of a decoded JSON object
+func jsonField(obj map[string]any, path string) (any, bool) {
+	var v any = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// jsonTime converts an RFC 3339 string or a Unix timestamp in seconds or
+// milliseconds to a time
+func jsonTime(v any) time.Time {
+	switch v := v.(type) {
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t
+		}
+	case float64:
+		if v > 1e12 {
+			return time.UnixMilli(int64(v))
+		}
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9))
+	}
+	return time.Time{}
+}
+
+// collectText counts the codes found anywhere in plain text lines
+func collectText(stats *errscan.Stats, r io.Reader, all bool) error {
+	s := errscan.NewScanner(r)
+	s.IncludeUnknown = all
+	for s.Scan() {
+		matches := s.Matches()
+		if len(matches) == 0 {
+			continue
+		}
+		t := lineTime(s.Bytes())
+		for _, m := range matches {
+			stats.Add(m.Code, t)
+		}
+	}
+	return s.Err()
+}
+
+// collectJSON counts the codes in a field of JSON lines and returns the
+// number of lines that are not JSON objects
+func collectJSON(stats *errscan.Stats, r io.Reader, field, timeField string, all bool) (int, error) {
+	skipped := 0
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for s.Scan() {
+		line := bytes.TrimSpace(s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(line, &obj); err != nil {
+			skipped++
+			continue
+		}
+		v, ok := jsonField(obj, field)
+		text, isString := v.(string)
+		if !ok || !isString {
+			continue
+		}
+		code, err := errors.Decode(strings.TrimSpace(text))
+		if err != nil {
+			continue
+		}
+		if _, known := errors.Lookup(code); !known && !all {
+			continue
+		}
+		var t time.Time
+		if v, ok := jsonField(obj, timeField); ok {
+			t = jsonTime(v)
+		}
+		stats.Add(code, t)
+	}
+	return skipped, s.Err()
+}
+
+func runStats(args []string) int {
+	fs, catalogPath := newFlagSet("stats")
+	field := fs.String("field", "", "read JSON lines and take the code from this dotted field instead of scanning plain text")
+	timeField := fs.String("time-field", "time", "dotted field holding the timestamp of JSON lines")
+	bucket := fs.Duration("bucket", time.Hour, "width of the histogram buckets")
+	top := fs.Int("top", 10, "number of entries per table, 0 for all")
+	format := fs.String("format", "table", "output format: table, csv or json")
+	all := fs.Bool("all", false, "also count codes missing from the catalog")
+	fs.Parse(args)
+
+	if *format != "table" && *format != "csv" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown output format %q\n", *format)
+		return 2
+	}
+	if *bucket <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: -bucket must be positive")
+		return 2
+	}
+	if _, err := loadCatalog(*catalogPath, defaultLockPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading catalog: %v\n", err)
+		return 1
+	}
+
+	stats := errscan.NewStats(*bucket)
+	skipped := 0
+	err := inputs(fs.Args(), func(name string, r io.Reader) error {
+		if *field == "" {
+			return collectText(stats, r, *all)
+		}
+		n, err := collectJSON(stats, r, *field, *timeField, *all)
+		skipped += n
+		return err
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%d line(s) skipped: not a JSON object\n", skipped)
+	}
+
+	report := stats.Report(*top)
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	case "csv":
+		err = writeStatsCSV(os.Stdout, report)
+	default:
+		err = writeStatsTable(os.Stdout, report)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// writeStatsCSV writes one row per table entry with the table name in the
+// first column
+func writeStatsCSV(w io.Writer, r errscan.Report) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"table", "name", "code", "count"})
+	for _, c := range r.Codes {
+		cw.Write([]string{"code", c.Name, c.Code, strconv.Itoa(c.Count)})
+	}
+	for _, l := range r.Levels {
+		for _, c := range l.Counts {
+			cw.Write([]string{l.Format + "." + l.Level, c.Name, "", strconv.Itoa(c.Count)})
+		}
+	}
+	for _, b := range r.Histogram {
+		cw.Write([]string{"bucket", b.Start.Format(time.RFC3339), "", strconv.Itoa(b.Count)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeStatsTable writes the tables as aligned text with a bar chart for
+// the histogram
+func writeStatsTable(w io.Writer, r errscan.Report) error {
+	// Counts are right aligned to the width of the total, which bounds them
+	width := len(strconv.Itoa(r.Total))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%d occurrence(s) of %d code(s)\n", r.Total, r.Distinct)
+
+	fmt.Fprintf(tw, "\nTop codes\n")
+	for _, c := range r.Codes {
+		name := c.Name
+		if name == "" {
+			name = "(not in catalog)"
+		}
+		fmt.Fprintf(tw, "%*d\t%s\t%s\n", width, c.Count, c.Code, name)
+	}
+	for _, l := range r.Levels {
+		fmt.Fprintf(tw, "\nBy %s %s\n", l.Format, strings.ReplaceAll(l.Level, "_", " "))
+		for _, c := range l.Counts {
+			fmt.Fprintf(tw, "%*d\t%s\n", width, c.Count, c.Name)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Histogram) == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "\nHistogram (%s buckets)\n", r.Bucket)
+	maxCount := 0
+	for _, b := range r.Histogram {
+		maxCount = max(maxCount, b.Count)
+	}
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, b := range r.Histogram {
+		bar := strings.Repeat("#", max(1, b.Count*40/maxCount))
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", b.Start.Format(time.RFC3339), b.Count, bar)
+	}
+	return tw.Flush()
+}
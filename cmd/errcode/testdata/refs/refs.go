@@ -0,0 +1,10 @@
+package refs
+
+import "github.com/thommeo/error-code-design/pkg/errors"
+
+func find(id string) error {
+	if id == "" {
+		return errors.NewTinyBadRequest("id is required")
+	}
+	return errors.New(errors.TinyNotFound, "no user "+id)
+}
@@ -0,0 +1,15 @@
+// Command errcodevet checks the use of error codes against the catalog. It
+// runs standalone or as a vet tool:
+//
+//	go vet -vettool=$(which errcodevet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/thommeo/error-code-design/pkg/errors/codecheck"
+)
+
+func main() {
+	singlechecker.Main(codecheck.Analyzer)
+}
@@ -0,0 +1,525 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+const sdkTemplate = `// Code generated by sdkgen. DO NOT EDIT.
+//
+// Error code catalog and encode/decode functions, bit-compatible with the
+// Go implementation in github.com/thommeo/error-code-design/pkg/errors.
+
+export interface LayoutField {
+  name: string;
+  bits: number;
+}
+
+export interface Layout {
+  type: number;
+  width: number;
+  fields: LayoutField[];
+}
+
+export interface CatalogEntry {
+  code: string;
+  path: string;
+  description: string;
+  fields: Record<string, string>;
+  descriptions?: Record<string, string>;
+  messages?: Record<string, string>;
+  status?: "deprecated" | "retired";
+  deprecatedSince?: string;
+  replacement?: string;
+}
+
+export type ErrorCodeErrorKind = "invalid_length" | "invalid_char" | "unknown_type" | "overflow";
+
+export class ErrorCodeError extends Error {
+  constructor(
+    readonly kind: ErrorCodeErrorKind,
+    readonly code: string,
+    readonly pos: number = -1,
+  ) {
+    super(pos >= 0 ? ` + "`" + `decode "${code}": ${kind} at position ${pos}` + "`" + ` : ` + "`" + `"${code}": ${kind}` + "`" + `);
+    this.name = "ErrorCodeError";
+  }
+}
+
+const BASE36 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ";
+
+export function toBase36(num: number, width: number): string {
+  if (!Number.isInteger(num) || num < 0 || num >= 36 ** width) {
+    throw new ErrorCodeError("overflow", String(num));
+  }
+  let result = "";
+  for (let i = 0; i < width; i++) {
+    result = BASE36[num % 36] + result;
+    num = Math.floor(num / 36);
+  }
+  return result;
+}
+
+export function fromBase36(s: string, code: string = s, offset: number = 0): number {
+  let result = 0;
+  for (let i = 0; i < s.length; i++) {
+    const val = BASE36.indexOf(s[i]);
+    if (val < 0) {
+      throw new ErrorCodeError("invalid_char", code, offset + i);
+    }
+    result = result * 36 + val;
+  }
+  return result;
+}
+
+function bits(layout: Layout): number {
+  return layout.fields.reduce((sum, f) => sum + f.bits, 0);
+}
+
+function capacity(layout: Layout): number {
+  return Math.min(2 ** bits(layout), 36 ** layout.width);
+}
+
+function maxValue(layout: Layout, i: number): number {
+  let max = 2 ** layout.fields[i].bits - 1;
+  if (i === 0) {
+    // The most significant field is also limited by the data width
+    const shift = bits(layout) - layout.fields[0].bits;
+    max = Math.min(max, Math.floor((capacity(layout) - 1) / 2 ** shift));
+  }
+  return max;
+}
+
+export function encodeLayout(layout: Layout, values: number[]): string {
+  if (values.length !== layout.fields.length) {
+    throw new Error(` + "`" + `layout has ${layout.fields.length} fields, got ${values.length} values` + "`" + `);
+  }
+  let packed = 0;
+  layout.fields.forEach((f, i) => {
+    const v = values[i];
+    if (!Number.isInteger(v) || v < 0 || v > maxValue(layout, i)) {
+      throw new ErrorCodeError("overflow", ` + "`" + `${f.name}=${v}` + "`" + `);
+    }
+    packed = packed * 2 ** f.bits + v;
+  });
+  return "E" + toBase36(layout.type, 1) + toBase36(packed, layout.width);
+}
+
+export function decodeLayout(layout: Layout, code: string): number[] {
+  if (code.length !== layout.width + 2) {
+    throw new ErrorCodeError("invalid_length", code);
+  }
+  if (code[0] !== "E") {
+    throw new ErrorCodeError("invalid_char", code, 0);
+  }
+  if (fromBase36(code.slice(1, 2), code, 1) !== layout.type) {
+    throw new ErrorCodeError("unknown_type", code, 1);
+  }
+  let packed = fromBase36(code.slice(2), code, 2);
+  if (packed >= capacity(layout)) {
+    throw new ErrorCodeError("overflow", code);
+  }
+  const values = new Array<number>(layout.fields.length);
+  for (let i = layout.fields.length - 1; i >= 0; i--) {
+    const size = 2 ** layout.fields[i].bits;
+    values[i] = packed % size;
+    packed = Math.floor(packed / size);
+  }
+  return values;
+}
+{{range .Formats}}
+// {{.Title}}
+
+export const {{.Prefix}}Layout: Layout = {{.Layout}};
+
+export interface {{.Prefix}}Code {
+{{- range .Fields}}
+  {{.}}: number;
+{{- end}}
+}
+
+export type {{.Prefix}}CodeString =
+{{- range .Entries}}
+  | "{{.Code}}"
+{{- end}};
+
+export type {{.Prefix}}Path =
+{{- range .Entries}}
+  | "{{.Path}}"
+{{- end}};
+
+export const {{.Prefix}}Catalog: Record<{{.Prefix}}CodeString, CatalogEntry> = {{.Catalog}};
+
+export function encode{{.Prefix}}Code(c: {{.Prefix}}Code): string {
+  return encodeLayout({{.Prefix}}Layout, [{{range $i, $f := .Fields}}{{if $i}}, {{end}}c.{{$f}}{{end}}]);
+}
+
+export function decode{{.Prefix}}Code(code: string): {{.Prefix}}Code {
+  const v = decodeLayout({{.Prefix}}Layout, code);
+  return { {{- range $i, $f := .Fields}}{{if $i}},{{end}} {{$f}}: v[{{$i}}]{{end}} };
+}
+{{end}}
+export type ErrorCodeString = {{range $i, $f := .Formats}}{{if $i}} | {{end}}{{$f.Prefix}}CodeString{{end}};
+
+export const layouts: Record<string, Layout> = {
+{{- range .Formats}}
+  {{.Name}}: {{.Prefix}}Layout,
+{{- end}}
+};
+
+export const catalog: Record<string, CatalogEntry> = {
+{{- range .Formats}}
+  ...{{.Prefix}}Catalog,
+{{- end}}
+};
+
+export interface DecodedCode {
+  format: string;
+  code: string;
+  values: Record<string, number>;
+  entry?: CatalogEntry;
+}
+
+// decode reads the type character following the E prefix and decodes the
+// code with the matching format
+export function decode(code: string): DecodedCode {
+  if (code.length < 2) {
+    throw new ErrorCodeError("invalid_length", code);
+  }
+  if (code[0] !== "E") {
+    throw new ErrorCodeError("invalid_char", code, 0);
+  }
+  const type = fromBase36(code.slice(1, 2), code, 1);
+  for (const [format, layout] of Object.entries(layouts)) {
+    if (layout.type !== type) {
+      continue;
+    }
+    const values = decodeLayout(layout, code);
+    const named: Record<string, number> = {};
+    layout.fields.forEach((f, i) => (named[f.name] = values[i]));
+    return { format, code, values: named, entry: catalog[code] };
+  }
+  throw new ErrorCodeError("unknown_type", code, 1);
+}
+
+export const DEFAULT_LOCALE = "en";
+
+// localeFallbacks returns the locales tried for a locale, from the most to
+// the least specific, ending with the default locale: de-AT, de, en
+export function localeFallbacks(locale: string): string[] {
+  const chain: string[] = [];
+  let l = locale.trim().replace(/_/g, "-");
+  while (l !== "") {
+    chain.push(l);
+    const i = l.lastIndexOf("-");
+    if (i < 0) {
+      break;
+    }
+    l = l.slice(0, i);
+  }
+  if (chain.length === 0 || chain[chain.length - 1].toLowerCase() !== DEFAULT_LOCALE) {
+    chain.push(DEFAULT_LOCALE);
+  }
+  return chain;
+}
+
+function localized(texts: Record<string, string> | undefined, locale: string): string | undefined {
+  if (!texts) {
+    return undefined;
+  }
+  for (const l of localeFallbacks(locale)) {
+    const key = Object.keys(texts).find((k) => k.toLowerCase() === l.toLowerCase());
+    if (key !== undefined) {
+      return texts[key];
+    }
+  }
+  return undefined;
+}
+
+// description returns the description of a code in the given locale,
+// falling back to English
+export function description(code: string, locale: string = DEFAULT_LOCALE): string | undefined {
+  const entry = catalog[code];
+  return entry && (localized(entry.descriptions, locale) ?? entry.description);
+}
+
+// message returns the end-user message of a code in the given locale,
+// falling back to the localized description
+export function message(code: string, locale: string = DEFAULT_LOCALE): string | undefined {
+  const entry = catalog[code];
+  return entry && (localized(entry.messages, locale) ?? description(code, locale));
+}
+`
+
+type SDKFormat struct {
+	Name    string
+	Title   string
+	Prefix  string
+	Fields  []string
+	Layout  string
+	Catalog string
+	Entries []SDKEntry
+}
+
+type SDKEntry struct {
+	Code string
+	Path string
+}
+
+type SDKData struct {
+	Formats []SDKFormat
+}
+
+type layoutJSON struct {
+	Type   errors.CodeType   `json:"type"`
+	Width  int               `json:"width"`
+	Fields []layoutFieldJSON `json:"fields"`
+}
+
+type layoutFieldJSON struct {
+	Name string `json:"name"`
+	Bits int    `json:"bits"`
+}
+
+type entryJSON struct {
+	Code            string            `json:"code"`
+	Path            string            `json:"path"`
+	Description     string            `json:"description"`
+	Fields          map[string]string `json:"fields"`
+	Descriptions    map[string]string `json:"descriptions,omitempty"`
+	Messages        map[string]string `json:"messages,omitempty"`
+	Status          errors.Status     `json:"status,omitempty"`
+	DeprecatedSince string            `json:"deprecatedSince,omitempty"`
+	Replacement     string            `json:"replacement,omitempty"`
+}
+
+// getFormats collects the layout and catalog of every layout-based format
+func getFormats() ([]SDKFormat, error) {
+	var formats []SDKFormat
+
+	for _, f := range errors.Formats() {
+		if f.Layout == nil {
+			fmt.Fprintf(os.Stderr, "Skipping format %q without layout\n", f.Name)
+			continue
+		}
+
+		sf := SDKFormat{
+			Name:   f.Name,
+			Title:  f.Prototype.GetDocSection().Title,
+			Prefix: naming.GoName(f.Name),
+		}
+
+		lj := layoutJSON{Type: f.Layout.Type, Width: f.Layout.Width}
+		for _, field := range f.Layout.Fields {
+			key := naming.FieldKey(field.Name)
+			lj.Fields = append(lj.Fields, layoutFieldJSON{Name: key, Bits: field.Bits})
+			sf.Fields = append(sf.Fields, key)
+		}
+		layout, err := json.MarshalIndent(lj, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		sf.Layout = string(layout)
+
+		perms := f.Prototype.GetPermutations()
+		sort.Slice(perms, func(i, j int) bool {
+			return perms[i].Code < perms[j].Code
+		})
+
+		entries := map[string]entryJSON{}
+		for _, p := range perms {
+			code, err := f.Decode(p.Code)
+			if err != nil {
+				return nil, err
+			}
+			fields := map[string]string{}
+			for k, v := range p.Fields {
+				if k != "Description" {
+					fields[naming.FieldKey(k)] = v
+				}
+			}
+			entry := entryJSON{
+				Code:         p.Code,
+				Path:         code.String(),
+				Description:  p.Fields["Description"],
+				Fields:       fields,
+				Descriptions: p.Localization.Descriptions,
+				Messages:     p.Localization.Messages,
+			}
+			if p.Lifecycle.Deprecated() {
+				entry.Status = p.Lifecycle.State()
+				entry.DeprecatedSince = p.Lifecycle.DeprecatedSince
+				entry.Replacement = p.Lifecycle.Replacement
+			}
+			entries[p.Code] = entry
+			sf.Entries = append(sf.Entries, SDKEntry{Code: p.Code, Path: code.String()})
+		}
+		catalog, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		sf.Catalog = string(catalog)
+
+		formats = append(formats, sf)
+	}
+	return formats, nil
+}
+
+// generate renders the SDK source
+func generate(formats []SDKFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("sdk").Parse(sdkTemplate))
+	if err := tmpl.Execute(&buf, SDKData{Formats: formats}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Fixture lists codes encoded and rejected by the Go implementation. The
+// SDK tests check that the TypeScript functions agree with it.
+type Fixture struct {
+	Valid   []FixtureCode    `json:"valid"`
+	Invalid []FixtureInvalid `json:"invalid"`
+}
+
+// FixtureCode is a code with its field values and its path if it is in
+// the catalog
+type FixtureCode struct {
+	Format string            `json:"format"`
+	Code   string            `json:"code"`
+	Values map[string]uint32 `json:"values"`
+	Path   string            `json:"path,omitempty"`
+}
+
+// FixtureInvalid is a code rejected by Decode with the ErrorCodeError kind
+// and position the SDK reports for it
+type FixtureInvalid struct {
+	Code string `json:"code"`
+	Kind string `json:"kind"`
+	Pos  int    `json:"pos"`
+}
+
+// invalidCodes are malformed codes, one for every ErrorCodeError kind
+var invalidCodes = []string{"E", "E00", "e001", "E00!", "EZ000", "E3ZZZZ"}
+
+// errorKinds maps the decode errors to ErrorCodeError kinds
+var errorKinds = map[error]string{
+	errors.ErrInvalidLength: "invalid_length",
+	errors.ErrInvalidChar:   "invalid_char",
+	errors.ErrUnknownType:   "unknown_type",
+	errors.ErrOverflow:      "overflow",
+}
+
+// fixture encodes the catalog codes and the zero and maximum values of
+// every field of each layout-based format, and decodes invalidCodes
+func fixture() ([]byte, error) {
+	var fx Fixture
+	for _, f := range errors.Formats() {
+		if f.Layout == nil {
+			continue
+		}
+		var values [][]uint32
+		for _, p := range f.Prototype.GetPermutations() {
+			v, err := f.Layout.Decode(p.Code)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		values = append(values, make([]uint32, len(f.Layout.Fields)))
+		for i := range f.Layout.Fields {
+			v := make([]uint32, len(f.Layout.Fields))
+			v[i] = f.Layout.MaxValue(i)
+			values = append(values, v)
+		}
+
+		seen := map[string]bool{}
+		for _, v := range values {
+			encoded, err := f.Layout.Encode(v...)
+			if err != nil {
+				return nil, err
+			}
+			if seen[encoded] {
+				continue
+			}
+			seen[encoded] = true
+
+			fc := FixtureCode{Format: f.Name, Code: encoded, Values: map[string]uint32{}}
+			for i, field := range f.Layout.Fields {
+				fc.Values[naming.FieldKey(field.Name)] = v[i]
+			}
+			code, err := f.Decode(encoded)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := errors.Lookup(code); ok {
+				fc.Path = code.String()
+			}
+			fx.Valid = append(fx.Valid, fc)
+		}
+	}
+
+	for _, code := range invalidCodes {
+		_, err := errors.Decode(code)
+		var decodeErr *errors.DecodeError
+		if !stderrors.As(err, &decodeErr) {
+			return nil, fmt.Errorf("decode %q: got %v, want a decode error", code, err)
+		}
+		fx.Invalid = append(fx.Invalid, FixtureInvalid{Code: code, Kind: errorKinds[decodeErr.Err], Pos: decodeErr.Pos})
+	}
+
+	data, err := json.MarshalIndent(fx, "", "  ")
+	return append(data, '\n'), err
+}
+
+// writeFile writes data, creating the directory of path
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func main() {
+	output := flag.String("o", "sdk/typescript/error-codes.ts", "output file")
+	fixturePath := flag.String("fixture", "sdk/typescript/testdata/codes.json", "round-trip fixture read by the SDK tests")
+	flag.Parse()
+
+	formats, err := getFormats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error collecting formats: %v\n", err)
+		os.Exit(1)
+	}
+
+	src, err := generate(formats)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating SDK: %v\n", err)
+		os.Exit(1)
+	}
+	if err := writeFile(*output, src); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing SDK: %v\n", err)
+		os.Exit(1)
+	}
+
+	fx, err := fixture()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating fixture: %v\n", err)
+		os.Exit(1)
+	}
+	if err := writeFile(*fixturePath, fx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing fixture: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("SDK generated successfully")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	stderrors "errors"
+	"flag"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/thommeo/error-code-design/pkg/catalog"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+var update = flag.Bool("update", false, "update testdata/golden.ts")
+
+// checkFile compares generated data to the file at path
+func checkFile(t *testing.T, path string, got []byte, hint string) {
+	t.Helper()
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date, %s", path, hint)
+	}
+}
+
+func TestGenerateGolden(t *testing.T) {
+	saved := &catalog.Catalog{
+		Tiny:         errors.TinyCodeValues,
+		Simple:       errors.SimpleCodeTree,
+		Simple511:    errors.Simple511CodeTree,
+		AppComponent: errors.CodeTree,
+	}
+	defer saved.Install()
+
+	// The test catalog has lifecycle metadata and translations
+	c, err := catalog.Load("../../pkg/catalog/testdata/catalog.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Install()
+
+	formats, err := getFormats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(formats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile("testdata/golden.ts", got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkFile(t, "testdata/golden.ts", got, "run go test ./cmd/sdkgen -update")
+}
+
+func TestSDKUpToDate(t *testing.T) {
+	formats, err := getFormats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(formats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFile(t, "../../sdk/typescript/error-codes.ts", src, "run make sdk")
+
+	fx, err := fixture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFile(t, "../../sdk/typescript/testdata/codes.json", fx, "run make sdk")
+}
+
+func TestInvalidCodes(t *testing.T) {
+	// Every ErrorCodeError kind is covered
+	kinds := map[string]bool{}
+	for _, code := range invalidCodes {
+		_, err := errors.Decode(code)
+		for sentinel, kind := range errorKinds {
+			if err != nil && stderrors.Is(err, sentinel) {
+				kinds[kind] = true
+			}
+		}
+	}
+	if len(kinds) != len(errorKinds) {
+		t.Errorf("invalid codes cover kinds %v; want all of %v", kinds, errorKinds)
+	}
+}
+
+// TestTypeScript runs the SDK tests against the fixture if Node.js can run
+// TypeScript
+func TestTypeScript(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	if exec.Command(node, "--experimental-transform-types", "-e", "").Run() != nil {
+		t.Skip("node does not support --experimental-transform-types")
+	}
+	cmd := exec.Command(node, "--experimental-transform-types", "--test", "error-codes.test.ts")
+	cmd.Dir = "../../sdk/typescript"
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("SDK tests failed: %v\n%s", err, out)
+	}
+}
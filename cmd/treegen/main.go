@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/thommeo/error-code-design/pkg/catalog"
+)
+
+const header = `// Code generated by treegen from pkg/catalog/errcodes.yaml. DO NOT EDIT.
+
+package errors
+`
+
+// tree is a generated variable holding one section of the catalog
+type tree struct {
+	name string
+	doc  string
+	v    any
+}
+
+// literal writes v as a Go composite literal. Types are written without
+// package qualifier as they belong to the generated package; element types
+// of slices are elided. Zero fields are left out except Value and Name.
+func literal(b *bytes.Buffer, v reflect.Value, elided bool) {
+	switch v.Kind() {
+	case reflect.Slice:
+		if !elided {
+			fmt.Fprintf(b, "[]%s", v.Type().Elem().Name())
+		}
+		b.WriteString("{\n")
+		for i := 0; i < v.Len(); i++ {
+			literal(b, v.Index(i), true)
+			b.WriteString(",\n")
+		}
+		b.WriteString("}")
+	case reflect.Struct:
+		if !elided {
+			b.WriteString(v.Type().Name())
+		}
+		b.WriteString("{\n")
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if v.Field(i).IsZero() && f.Name != "Value" && f.Name != "Name" {
+				continue
+			}
+			fmt.Fprintf(b, "%s: ", f.Name)
+			literal(b, v.Field(i), false)
+			b.WriteString(",\n")
+		}
+		b.WriteString("}")
+	case reflect.Map:
+		b.WriteString("map[string]string{\n")
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(b, "%q: %q,\n", k, v.MapIndex(reflect.ValueOf(k)).String())
+		}
+		b.WriteString("}")
+	case reflect.String:
+		b.WriteString(strconv.Quote(v.String()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(b, "%d", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fmt.Fprintf(b, "%d", v.Uint())
+	default:
+		panic(fmt.Sprintf("unsupported catalog field kind %s", v.Kind()))
+	}
+}
+
+func main() {
+	output := flag.String("o", "pkg/errors/catalog_gen.go", "output file")
+	flag.Parse()
+
+	c, err := catalog.Builtin()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading built-in catalog: %v\n", err)
+		os.Exit(1)
+	}
+
+	trees := []tree{
+		{"TinyCodeValues", "the error types of the tiny format", c.Tiny},
+		{"SimpleCodeTree", "the classes and error types of the simple format", c.Simple},
+		{"Simple511CodeTree", "the classes and error types of the simple511 format", c.Simple511},
+		{"CodeTree", "the apps, components, sub-components and error types of the app_component format", c.AppComponent},
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	for _, t := range trees {
+		fmt.Fprintf(&buf, "\n// %s holds %s. Changes made once codes\n// are in use take effect through ReplaceTrees.\nvar %s = ", t.name, t.doc, t.name)
+		literal(&buf, reflect.ValueOf(t.v), false)
+		buf.WriteString("\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting code: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing code: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Code trees generated successfully")
+}
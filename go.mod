@@ -1,3 +1,13 @@
 module github.com/thommeo/error-code-design
 
 go 1.22.4
+
+require (
+	golang.org/x/tools v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+)
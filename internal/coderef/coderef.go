@@ -0,0 +1,193 @@
+// Package coderef resolves Go syntax that refers to catalog codes: the
+// generated code variables and constructors, composite literals of the code
+// types and string literals holding encoded codes. It is shared by the
+// static analyzer and the reference report.
+package coderef
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// ErrorsPkgPath is the import path of the package declaring the code types
+// and the generated codes
+var ErrorsPkgPath = reflect.TypeOf(errors.Error{}).PkgPath()
+
+// Kind is the syntax used by a reference
+type Kind int
+
+const (
+	Name    Kind = iota // Generated variable or constructor, e.g. errors.TinyNotFound
+	Literal             // Composite literal of a code type
+	String              // String literal holding an encoded code
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Name:
+		return "name"
+	case Literal:
+		return "literal"
+	case String:
+		return "string"
+	}
+	return "unknown"
+}
+
+// Names maps the identifiers of the generated code variables and their
+// constructors to the encoded codes, as named by constgen for the current
+// catalog
+func Names() map[string]string {
+	names := map[string]string{}
+	for _, f := range errors.Formats() {
+		if reflect.TypeOf(f.Prototype).PkgPath() != ErrorsPkgPath {
+			continue
+		}
+		for _, p := range f.Prototype.GetPermutations() {
+			code, err := f.Decode(p.Code)
+			if err != nil {
+				continue
+			}
+			name := naming.CodePrefix(f.Name) + naming.GoName(code.String())
+			names[name] = p.Code
+			names["New"+name] = p.Code
+		}
+	}
+	return names
+}
+
+// Ident returns the encoded code named by an identifier referring to a
+// generated code variable or constructor
+func Ident(info *types.Info, names map[string]string, id *ast.Ident) (string, bool) {
+	obj := info.Uses[id]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != ErrorsPkgPath || obj.Parent() != obj.Pkg().Scope() {
+		return "", false
+	}
+	switch obj.(type) {
+	case *types.Var, *types.Func:
+		code, ok := names[obj.Name()]
+		return code, ok
+	}
+	return "", false
+}
+
+// FormatOf returns the format whose code type is t
+func FormatOf(t types.Type) (errors.Format, bool) {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return errors.Format{}, false
+	}
+	for _, f := range errors.Formats() {
+		rt := reflect.TypeOf(f.Prototype)
+		if rt.PkgPath() == named.Obj().Pkg().Path() && rt.Name() == named.Obj().Name() {
+			return f, true
+		}
+	}
+	return errors.Format{}, false
+}
+
+// Composite returns the code built by a composite literal of a code type.
+// The struct fields of a code type are in layout order. ok is false if the
+// literal is not of a code type, is empty or a field value is not constant;
+// err is set if the values do not fit the layout. Empty literals are left out
+// as they usually stand for "no code", e.g. next to a returned error.
+func Composite(info *types.Info, lit *ast.CompositeLit) (encoded string, err error, ok bool) {
+	tv, found := info.Types[lit]
+	if !found || len(lit.Elts) == 0 {
+		return "", nil, false
+	}
+	f, found := FormatOf(tv.Type)
+	if !found || f.Layout == nil {
+		return "", nil, false
+	}
+	st, isStruct := tv.Type.Underlying().(*types.Struct)
+	if !isStruct || st.NumFields() != len(f.Layout.Fields) {
+		return "", nil, false
+	}
+
+	values := make([]uint32, st.NumFields())
+	for i, elt := range lit.Elts {
+		index, value := i, elt
+		if kv, isKV := elt.(*ast.KeyValueExpr); isKV {
+			key, isIdent := kv.Key.(*ast.Ident)
+			if !isIdent {
+				return "", nil, false
+			}
+			index = fieldIndex(st, key.Name)
+			value = kv.Value
+		}
+		v, isConst := constValue(info, value)
+		if index < 0 || !isConst {
+			return "", nil, false
+		}
+		values[index] = v
+	}
+
+	encoded, err = f.Layout.Encode(values...)
+	return encoded, err, true
+}
+
+func fieldIndex(st *types.Struct, name string) int {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func constValue(info *types.Info, expr ast.Expr) (uint32, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+	v, exact := constant.Uint64Val(tv.Value)
+	if !exact || v > 1<<32-1 {
+		return 0, false
+	}
+	return uint32(v), true
+}
+
+// StringLit returns the value of a string literal that looks like a code
+func StringLit(lit *ast.BasicLit) (string, bool) {
+	if lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil || !LooksLikeCode(s) {
+		return "", false
+	}
+	return s, true
+}
+
+// LooksLikeCode reports whether s has the shape of a code: an E, the type
+// character of a registered format and base36 characters. To tell codes of
+// the wrong length from words like "EACCES", s must either have the length
+// of the format's codes or contain a digit.
+func LooksLikeCode(s string) bool {
+	if len(s) < 3 || len(s) > 8 || s[0] != 'E' {
+		return false
+	}
+	digit := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			digit = digit || i > 1
+		case s[i] < 'A' || s[i] > 'Z':
+			return false
+		}
+	}
+	typ, err := strconv.ParseUint(s[1:2], 36, 16)
+	if err != nil {
+		return false
+	}
+	f, ok := errors.LookupFormat(errors.CodeType(typ))
+	return ok && (digit || len(s) == f.Length())
+}
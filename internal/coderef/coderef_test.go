@@ -0,0 +1,92 @@
+package coderef
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestLooksLikeCode(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"EA0MTXD", true},
+		{"E10075", true},
+		{"E1007", true}, // Wrong length but has a digit
+		{"EACCES", false},
+		{"ERROR", false}, // No format with type R
+		{"E", false},
+		{"Ea0MTXD", false},
+	}
+	for _, tt := range tests {
+		if got := LooksLikeCode(tt.s); got != tt.want {
+			t.Errorf("LooksLikeCode(%q) = %v; want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+const src = `package p
+
+import "github.com/thommeo/error-code-design/pkg/errors"
+
+var (
+	a = errors.BackendHandlerUsersValidationError
+	b = errors.NewSimpleJobsTimeout("timeout")
+	c = errors.SimpleCode{Class: 1, ErrType: 1}
+	d = errors.TinyCode{}
+	e = "E002"
+	f = "E1007"
+	g = errors.Simple511Code{Class: 32}
+)
+`
+
+func TestCollect(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}, Uses: map[*ast.Ident]types.Object{}}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Ref{
+		{Code: "EA0MTXD", Kind: Name, File: "p.go", Line: 6, Column: 13},
+		{Code: "E100EA", Kind: Name, File: "p.go", Line: 7, Column: 13},
+		{Code: "E10075", Kind: Literal, File: "p.go", Line: 8, Column: 6},
+		{Code: "E002", Kind: String, File: "p.go", Line: 10, Column: 6},
+	}
+	got := Collect(fset, file, info, Names())
+	if len(got) != len(want) {
+		t.Fatalf("Collect() = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ref %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestKindJSON(t *testing.T) {
+	data, err := json.Marshal([]Kind{Name, Literal, String})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["name","literal","string"]` {
+		t.Errorf("Marshal() = %s", data)
+	}
+	var kinds []Kind
+	if err := json.Unmarshal(data, &kinds); err != nil || len(kinds) != 3 || kinds[2] != String {
+		t.Errorf("Unmarshal() = %v, %v", kinds, err)
+	}
+	if err := json.Unmarshal([]byte(`["other"]`), &kinds); err == nil {
+		t.Error("Unmarshal() of unknown kind succeeded")
+	}
+}
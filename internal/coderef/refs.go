@@ -0,0 +1,102 @@
+package coderef
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// Ref is a reference to a code in Go source
+type Ref struct {
+	Code   string `json:"code"`
+	Kind   Kind   `json:"kind"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s:%d:%d", r.File, r.Line, r.Column)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (k *Kind) UnmarshalText(text []byte) error {
+	for _, kind := range []Kind{Name, Literal, String} {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown reference kind %q", text)
+}
+
+// Collect returns the references to codes in a type-checked file. Codes
+// that do not decode are left out; codes missing from the catalog are kept.
+// names is the result of Names.
+func Collect(fset *token.FileSet, file *ast.File, info *types.Info, names map[string]string) []Ref {
+	var refs []Ref
+	add := func(n ast.Node, code string, kind Kind) {
+		if _, err := errors.Decode(code); err != nil {
+			return
+		}
+		pos := fset.Position(n.Pos())
+		refs = append(refs, Ref{Code: code, Kind: kind, File: pos.Filename, Line: pos.Line, Column: pos.Column})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if code, ok := Ident(info, names, n); ok {
+				add(n, code, Name)
+			}
+		case *ast.CompositeLit:
+			if code, err, ok := Composite(info, n); ok && err == nil {
+				add(n, code, Literal)
+				return false
+			}
+		case *ast.BasicLit:
+			if code, ok := StringLit(n); ok {
+				add(n, code, String)
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+// Entry is a catalog code with its references, as written by errcode refs
+// -json
+type Entry struct {
+	Code   string `json:"code"`
+	Format string `json:"format"`
+	Path   string `json:"path,omitempty"` // Empty for codes missing from the catalog
+	Refs   []Ref  `json:"refs"`
+}
+
+// LoadEntries reads the entries written by errcode refs -json, keyed by
+// code
+func LoadEntries(path string) (map[string]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	byCode := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		byCode[e.Code] = e
+	}
+	return byCode, nil
+}
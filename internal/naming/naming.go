@@ -0,0 +1,63 @@
+// Package naming converts catalog names into Go identifiers. It is shared by
+// the code generators and the tools that map identifiers back to codes.
+package naming
+
+import "strings"
+
+// initialisms are written in upper case, following Go naming conventions
+var initialisms = map[string]string{
+	"api":  "API",
+	"db":   "DB",
+	"grpc": "GRPC",
+	"http": "HTTP",
+	"id":   "ID",
+	"json": "JSON",
+	"pdf":  "PDF",
+	"sql":  "SQL",
+	"ui":   "UI",
+	"url":  "URL",
+}
+
+// GoName joins snake_case or dotted name parts into an exported CamelCase
+// identifier, e.g. "backend.handler.users.validation_error" becomes
+// "BackendHandlerUsersValidationError"
+func GoName(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for _, word := range strings.FieldsFunc(part, isSeparator) {
+			word = strings.ToLower(word)
+			if upper, ok := initialisms[word]; ok {
+				b.WriteString(upper)
+				continue
+			}
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+func isSeparator(r rune) bool {
+	return r == '.' || r == '_' || r == '-' || r == ' '
+}
+
+// FieldKey turns a layout field name like "SubComponent" into the key
+// "sub_component" used for the field in logs, metrics and other output
+func FieldKey(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// CodePrefix returns the identifier prefix used for codes of a format.
+// App component codes carry their app name and need no prefix.
+func CodePrefix(format string) string {
+	if format == "app_component" {
+		return ""
+	}
+	return GoName(format)
+}
@@ -0,0 +1,38 @@
+package naming
+
+import "testing"
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"backend.handler.users.validation_error"}, "BackendHandlerUsersValidationError"},
+		{[]string{"Simple", "api.authorization_error"}, "SimpleAPIAuthorizationError"},
+		{[]string{"simple511", "http.not_found"}, "Simple511HTTPNotFound"},
+		{[]string{"frontend.ui.forms.submission_error"}, "FrontendUIFormsSubmissionError"},
+	}
+
+	for _, tt := range tests {
+		if got := GoName(tt.parts...); got != tt.want {
+			t.Errorf("GoName(%q) = %s; want %s", tt.parts, got, tt.want)
+		}
+	}
+}
+
+func TestFieldKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"App", "app"},
+		{"SubComponent", "sub_component"},
+		{"ErrorType", "error_type"},
+	}
+
+	for _, tt := range tests {
+		if got := FieldKey(tt.name); got != tt.want {
+			t.Errorf("FieldKey(%q) = %s; want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package catalog
+
+import _ "embed"
+
+//go:embed errcodes.yaml
+var builtinData []byte
+
+// BuiltinFile is the name of the embedded built-in catalog, relative to
+// this package
+const BuiltinFile = "errcodes.yaml"
+
+// Builtin parses the embedded built-in catalog. It is the source of the
+// trees compiled into the errors package, which are generated from it by
+// treegen.
+func Builtin() (*Catalog, error) {
+	return Parse(builtinData, BuiltinFile)
+}
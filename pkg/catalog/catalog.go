@@ -0,0 +1,174 @@
+// Package catalog loads error code catalogs from YAML or JSON files into the
+// trees used by the errors package.
+//
+// A catalog file has one optional section per format:
+//
+//	tiny:
+//	  - value: 1
+//	    name: validation
+//	    description: Validation error
+//	simple:
+//	  - value: 1
+//	    name: api
+//	    description: API related errors
+//	    error_types:
+//	      - value: 1
+//	        name: validation_error
+//	        description: API validation error
+//	simple511:
+//	  - ... # same shape as simple
+//	app_component:
+//	  - value: 1
+//	    name: backend
+//	    description: Backend application services
+//	    components:
+//	      - value: 1
+//	        name: handler
+//	        sub_components:
+//	          - value: 1
+//	            name: users
+//	            error_types:
+//	              - value: 1
+//	                name: validation_error
+//
+// Error types may set the HTTP status of their errors and carry translated
+// descriptions and end-user messages by locale:
+//
+//	http_status: 422
+//	descriptions:
+//	  de: API-Validierungsfehler
+//	messages:
+//	  en: Some of the entered data is invalid.
+//	  de: Einige der eingegebenen Daten sind ungültig.
+//
+// Every entry may also carry lifecycle metadata:
+//
+//	status: deprecated # active (default), deprecated or retired
+//	deprecated_since: v1.4.0
+//	replacement: EA0MTXD
+//
+// and operational metadata, inherited by the entries below it unless they
+// set their own:
+//
+//	severity: critical # info, warning, error (default) or critical
+//	owner: payments-team
+//
+// JSON files use the same keys. Sections missing from the file leave the
+// corresponding tree untouched when the catalog is installed.
+//
+// The built-in catalog is errcodes.yaml in this directory, embedded and
+// returned by Builtin. The trees of the errors package are generated from
+// it by treegen, as the errors package cannot depend on this one; edit the
+// file and run make generate rather than editing the generated trees.
+package catalog
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// Catalog holds the trees read from a catalog file. A nil section was not
+// present in the file.
+type Catalog struct {
+	Tiny         []errors.TinyErrorInfo
+	Simple       []errors.SimpleClassInfo
+	Simple511    []errors.Simple5ClassInfo
+	AppComponent []errors.AppInfo
+}
+
+// Error is a schema problem at a position in a catalog file
+type Error struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// ErrorList is the list of all problems found in a catalog file
+type ErrorList []Error
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Load reads and parses the catalog file at path
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, path)
+}
+
+// LoadFS reads and parses a catalog file from fsys, e.g. an embed.FS
+func LoadFS(fsys fs.FS, path string) (*Catalog, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, path)
+}
+
+// Parse parses YAML or JSON catalog data. The file name is only used in
+// error messages.
+func Parse(data []byte, file string) (*Catalog, error) {
+	d := &decoder{file: file}
+	c := d.catalog(data)
+	if len(d.errs) > 0 {
+		return nil, d.errs
+	}
+	return c, nil
+}
+
+// Install replaces the trees of the errors package with the sections
+// present in the catalog. It is safe to call while other goroutines use
+// codes.
+func (c *Catalog) Install() {
+	errors.ReplaceTrees(c.assign)
+}
+
+// assign sets the tree variables of the errors package to the sections
+// present in the catalog
+func (c *Catalog) assign() {
+	if c.Tiny != nil {
+		errors.TinyCodeValues = c.Tiny
+	}
+	if c.Simple != nil {
+		errors.SimpleCodeTree = c.Simple
+	}
+	if c.Simple511 != nil {
+		errors.Simple511CodeTree = c.Simple511
+	}
+	if c.AppComponent != nil {
+		errors.CodeTree = c.AppComponent
+	}
+}
+
+// InstallLocked installs the catalog like Install and checks it against
+// the allocation lock. If the catalog changes the path of a locked code the
+// previous trees are restored and the problems are returned.
+func (c *Catalog) InstallLocked(lock *errors.Lock) error {
+	return errors.ReplaceTreesChecked(c.assign, func() error {
+		problems := lock.Check(errors.TakeSnapshot())
+		if len(problems) == 0 {
+			return nil
+		}
+		msgs := make([]string, len(problems))
+		for i, p := range problems {
+			msgs[i] = p.String()
+		}
+		return fmt.Errorf("catalog conflicts with lock:\n%s", strings.Join(msgs, "\n"))
+	})
+}
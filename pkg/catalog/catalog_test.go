@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	stderrors "errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func TestLoadYAML(t *testing.T) {
+	c, err := Load("testdata/catalog.yaml")
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if c.Simple != nil || c.Simple511 != nil {
+		t.Error("sections missing from the file should be nil")
+	}
+	if len(c.Tiny) != 2 || c.Tiny[1].Value != 7 || c.Tiny[1].Name != "rate_limited" {
+		t.Errorf("Tiny = %+v", c.Tiny)
+	}
+	if l := c.Tiny[1].Lifecycle; l.String() != "deprecated since v1.2.0, use E000" {
+		t.Errorf("Tiny lifecycle = %v", l)
+	}
+
+	pdf := c.AppComponent[0].Components[0].SubComponents[1]
+	if pdf.Name != "pdf" || pdf.ErrorTypes[0].Name != "render_failed" || pdf.ErrorTypes[0].Description != "PDF rendering failed" || pdf.ErrorTypes[0].HTTPStatus != 503 {
+		t.Errorf("AppComponent sub-component = %+v", pdf)
+	}
+	if texts := pdf.ErrorTypes[0].Localization; texts.Descriptions["de"] != "PDF-Erzeugung fehlgeschlagen" || len(texts.Messages) != 2 {
+		t.Errorf("AppComponent error type localization = %+v", texts)
+	}
+	if c.AppComponent[0].Owner != "billing-team" || pdf.ErrorTypes[0].Severity != errors.SeverityCritical {
+		t.Errorf("AppComponent metadata = %+v, %+v", c.AppComponent[0].Metadata, pdf.ErrorTypes[0].Metadata)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	c, err := LoadFS(os.DirFS("testdata"), "catalog.json")
+	if err != nil {
+		t.Fatalf("LoadFS() returned error: %v", err)
+	}
+
+	if c.Tiny != nil || c.AppComponent != nil {
+		t.Error("sections missing from the file should be nil")
+	}
+	if c.Simple[0].ErrorTypes[0].Name != "disk_full" {
+		t.Errorf("Simple = %+v", c.Simple)
+	}
+	if c.Simple511[0].ErrorTypes[0].Value != 2047 {
+		t.Errorf("Simple511 = %+v", c.Simple511)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := Load("testdata/invalid.yaml")
+
+	var list ErrorList
+	if !stderrors.As(err, &list) {
+		t.Fatalf("Load() error = %v; want ErrorList", err)
+	}
+
+	want := []string{
+		`testdata/invalid.yaml:3:5: unknown field "nmae", expected one of: value, name, description, status, deprecated_since, replacement, severity, owner, http_status, descriptions, messages`,
+		`testdata/invalid.yaml:2:5: missing required field "name"`,
+		`testdata/invalid.yaml:4:12: expected an integer, got str`,
+		`testdata/invalid.yaml:6:15: expected a mapping, got a list`,
+		`testdata/invalid.yaml:8:12: value 300 out of range 0-255`,
+		`testdata/invalid.yaml:10:15: unknown severity "urgent", expected one of: info, warning, error, critical`,
+		`testdata/invalid.yaml:11:17: expected a list, got a mapping`,
+	}
+	if len(list) != len(want) {
+		t.Fatalf("Load() returned %d errors; want %d:\n%v", len(list), len(want), err)
+	}
+	for i := range want {
+		if list[i].Error() != want[i] {
+			t.Errorf("error %d = %s; want %s", i, list[i].Error(), want[i])
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	savedTiny, savedTree := errors.TinyCodeValues, errors.CodeTree
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues, errors.CodeTree = savedTiny, savedTree })
+
+	c, err := Load("testdata/catalog.yaml")
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	c.Install()
+
+	if got := (errors.TinyCode{ErrType: 7}).String(); got != "rate_limited" {
+		t.Errorf("TinyCode{7}.String() = %s; want rate_limited", got)
+	}
+}
+
+func TestInstallLocked(t *testing.T) {
+	savedTiny, savedTree := errors.TinyCodeValues, errors.CodeTree
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues, errors.CodeTree = savedTiny, savedTree })
+
+	c, err := Load("testdata/catalog.yaml")
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	lock := &errors.Lock{Entries: []errors.LockEntry{
+		{Code: "E007", Format: "tiny", Path: "payment_required", FirstSeen: "v1.0.0", Retired: true},
+	}}
+	if err := c.InstallLocked(lock); err == nil {
+		t.Fatal("InstallLocked() should refuse to reuse a retired code")
+	}
+	if got := (errors.TinyCode{ErrType: 7}).String(); got != "error_7" {
+		t.Errorf("trees should be restored after a failed install, TinyCode{7}.String() = %s", got)
+	}
+
+	lock.Entries[0].Path = "rate_limited"
+	if err := c.InstallLocked(lock); err != nil {
+		t.Errorf("InstallLocked() with a restored code returned error: %v", err)
+	}
+}
+
+func TestBuiltinMatchesTrees(t *testing.T) {
+	c, err := Builtin()
+	if err != nil {
+		t.Fatalf("Builtin() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(c.Tiny, errors.TinyCodeValues) ||
+		!reflect.DeepEqual(c.Simple, errors.SimpleCodeTree) ||
+		!reflect.DeepEqual(c.Simple511, errors.Simple511CodeTree) ||
+		!reflect.DeepEqual(c.AppComponent, errors.CodeTree) {
+		t.Error("compiled trees differ from the built-in catalog, run make generate")
+	}
+}
@@ -0,0 +1,339 @@
+package catalog
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// decoder walks the YAML node tree, collecting every schema problem with
+// its position instead of stopping at the first one
+type decoder struct {
+	file string
+	errs ErrorList
+}
+
+func (d *decoder) errorf(n *yaml.Node, format string, args ...any) {
+	d.errs = append(d.errs, Error{
+		File:   d.file,
+		Line:   n.Line,
+		Column: n.Column,
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *decoder) catalog(data []byte) *Catalog {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		d.errs = append(d.errs, Error{File: d.file, Line: 1, Column: 1, Msg: err.Error()})
+		return nil
+	}
+	if len(doc.Content) == 0 {
+		d.errs = append(d.errs, Error{File: d.file, Line: 1, Column: 1, Msg: "empty catalog"})
+		return nil
+	}
+
+	c := &Catalog{}
+	f := d.fields(doc.Content[0], "tiny", "simple", "simple511", "app_component")
+	if n, ok := f["tiny"]; ok {
+		c.Tiny = []errors.TinyErrorInfo{}
+		for _, item := range d.seq(n) {
+			c.Tiny = append(c.Tiny, d.tinyError(item))
+		}
+	}
+	if n, ok := f["simple"]; ok {
+		c.Simple = []errors.SimpleClassInfo{}
+		for _, item := range d.seq(n) {
+			c.Simple = append(c.Simple, d.simpleClass(item))
+		}
+	}
+	if n, ok := f["simple511"]; ok {
+		c.Simple511 = []errors.Simple5ClassInfo{}
+		for _, item := range d.seq(n) {
+			c.Simple511 = append(c.Simple511, d.simple511Class(item))
+		}
+	}
+	if n, ok := f["app_component"]; ok {
+		c.AppComponent = []errors.AppInfo{}
+		for _, item := range d.seq(n) {
+			c.AppComponent = append(c.AppComponent, d.app(item))
+		}
+	}
+	return c
+}
+
+func (d *decoder) tinyError(n *yaml.Node) errors.TinyErrorInfo {
+	e := d.entry(n, math.MaxUint16, errorTypeKeys...)
+	return errors.TinyErrorInfo{
+		Value:        uint16(e.value),
+		Name:         e.name,
+		Description:  e.description,
+		HTTPStatus:   e.httpStatus,
+		Localization: e.localization,
+		Lifecycle:    e.lifecycle,
+		Metadata:     e.metadata,
+	}
+}
+
+func (d *decoder) simpleClass(n *yaml.Node) errors.SimpleClassInfo {
+	e := d.entry(n, math.MaxUint8, "error_types")
+	class := errors.SimpleClassInfo{
+		Value:       errors.ClassCode(e.value),
+		Name:        e.name,
+		Description: e.description,
+		Lifecycle:   e.lifecycle,
+		Metadata:    e.metadata,
+	}
+	for _, item := range d.seq(e.fields["error_types"]) {
+		errType := d.entry(item, math.MaxUint8, errorTypeKeys...)
+		class.ErrorTypes = append(class.ErrorTypes, errors.SimpleErrorInfo{
+			Value:        errors.SimpleErrorCode(errType.value),
+			Name:         errType.name,
+			Description:  errType.description,
+			HTTPStatus:   errType.httpStatus,
+			Localization: errType.localization,
+			Lifecycle:    errType.lifecycle,
+			Metadata:     errType.metadata,
+		})
+	}
+	return class
+}
+
+func (d *decoder) simple511Class(n *yaml.Node) errors.Simple5ClassInfo {
+	e := d.entry(n, math.MaxUint8, "error_types")
+	class := errors.Simple5ClassInfo{
+		Value:       errors.Class5Code(e.value),
+		Name:        e.name,
+		Description: e.description,
+		Lifecycle:   e.lifecycle,
+		Metadata:    e.metadata,
+	}
+	for _, item := range d.seq(e.fields["error_types"]) {
+		errType := d.entry(item, math.MaxUint16, errorTypeKeys...)
+		class.ErrorTypes = append(class.ErrorTypes, errors.Simple11ErrorInfo{
+			Value:        errors.Simple11ErrorCode(errType.value),
+			Name:         errType.name,
+			Description:  errType.description,
+			HTTPStatus:   errType.httpStatus,
+			Localization: errType.localization,
+			Lifecycle:    errType.lifecycle,
+			Metadata:     errType.metadata,
+		})
+	}
+	return class
+}
+
+func (d *decoder) app(n *yaml.Node) errors.AppInfo {
+	e := d.entry(n, math.MaxUint8, "components")
+	app := errors.AppInfo{
+		Value:       errors.AppCode(e.value),
+		Name:        e.name,
+		Description: e.description,
+		Lifecycle:   e.lifecycle,
+		Metadata:    e.metadata,
+	}
+	for _, item := range d.seq(e.fields["components"]) {
+		app.Components = append(app.Components, d.component(item))
+	}
+	return app
+}
+
+func (d *decoder) component(n *yaml.Node) errors.ComponentInfo {
+	e := d.entry(n, math.MaxUint8, "sub_components")
+	comp := errors.ComponentInfo{
+		Value:       errors.ComponentCode(e.value),
+		Name:        e.name,
+		Description: e.description,
+		Lifecycle:   e.lifecycle,
+		Metadata:    e.metadata,
+	}
+	for _, item := range d.seq(e.fields["sub_components"]) {
+		comp.SubComponents = append(comp.SubComponents, d.subComponent(item))
+	}
+	return comp
+}
+
+func (d *decoder) subComponent(n *yaml.Node) errors.SubComponentInfo {
+	e := d.entry(n, math.MaxUint8, "error_types")
+	subComp := errors.SubComponentInfo{
+		Value:       errors.SubComponentCode(e.value),
+		Name:        e.name,
+		Description: e.description,
+		Lifecycle:   e.lifecycle,
+		Metadata:    e.metadata,
+	}
+	for _, item := range d.seq(e.fields["error_types"]) {
+		errType := d.entry(item, math.MaxUint8, errorTypeKeys...)
+		subComp.ErrorTypes = append(subComp.ErrorTypes, errors.ErrorInfo{
+			Value:        errors.ErrorCode(errType.value),
+			Name:         errType.name,
+			Description:  errType.description,
+			HTTPStatus:   errType.httpStatus,
+			Localization: errType.localization,
+			Lifecycle:    errType.lifecycle,
+			Metadata:     errType.metadata,
+		})
+	}
+	return subComp
+}
+
+// errorTypeKeys are the keys allowed on error types only
+var errorTypeKeys = []string{"http_status", "descriptions", "messages"}
+
+// entry holds the fields shared by every level of every tree
+type entry struct {
+	value        uint64
+	name         string
+	description  string
+	httpStatus   int
+	localization errors.Localization
+	lifecycle    errors.Lifecycle
+	metadata     errors.Metadata
+	fields       map[string]*yaml.Node
+}
+
+// entry decodes the common fields of a tree node, allowing the given extra
+// keys
+func (d *decoder) entry(n *yaml.Node, maxValue uint64, extra ...string) entry {
+	keys := []string{"value", "name", "description", "status", "deprecated_since", "replacement", "severity", "owner"}
+	f := d.fields(n, append(keys, extra...)...)
+	e := entry{fields: f}
+
+	if v, ok := f["value"]; ok {
+		e.value = d.uint(v, maxValue)
+	} else if n.Kind == yaml.MappingNode {
+		d.errorf(n, "missing required field \"value\"")
+	}
+	if v, ok := f["name"]; ok {
+		e.name = d.str(v)
+		if e.name == "" {
+			d.errorf(v, "name must not be empty")
+		}
+	} else if n.Kind == yaml.MappingNode {
+		d.errorf(n, "missing required field \"name\"")
+	}
+	if v, ok := f["description"]; ok {
+		e.description = d.str(v)
+	}
+	if v, ok := f["http_status"]; ok {
+		e.httpStatus = int(d.uint(v, 599))
+	}
+	if v, ok := f["descriptions"]; ok {
+		e.localization.Descriptions = d.texts(v)
+	}
+	if v, ok := f["messages"]; ok {
+		e.localization.Messages = d.texts(v)
+	}
+	if v, ok := f["status"]; ok {
+		e.lifecycle.Status = errors.Status(d.str(v))
+		if !e.lifecycle.Status.Valid() {
+			d.errorf(v, "unknown status %q, expected one of: active, deprecated, retired", v.Value)
+		}
+	}
+	if v, ok := f["deprecated_since"]; ok {
+		e.lifecycle.DeprecatedSince = d.str(v)
+	}
+	if v, ok := f["replacement"]; ok {
+		e.lifecycle.Replacement = d.str(v)
+	}
+	if v, ok := f["severity"]; ok {
+		e.metadata.Severity = errors.Severity(d.str(v))
+		if !e.metadata.Severity.Valid() {
+			d.errorf(v, "unknown severity %q, expected one of: info, warning, error, critical", v.Value)
+		}
+	}
+	if v, ok := f["owner"]; ok {
+		e.metadata.Owner = d.str(v)
+	}
+	return e
+}
+
+// fields returns the values of a mapping node by key, reporting unknown and
+// duplicate keys
+func (d *decoder) fields(n *yaml.Node, allowed ...string) map[string]*yaml.Node {
+	f := map[string]*yaml.Node{}
+	if n.Kind != yaml.MappingNode {
+		d.errorf(n, "expected a mapping, got %s", kindName(n))
+		return f
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		switch {
+		case !slices.Contains(allowed, key.Value):
+			d.errorf(key, "unknown field %q, expected one of: %s", key.Value, strings.Join(allowed, ", "))
+		case f[key.Value] != nil:
+			d.errorf(key, "duplicate field %q", key.Value)
+		default:
+			f[key.Value] = value
+		}
+	}
+	return f
+}
+
+// texts decodes a mapping of locales to translated texts
+func (d *decoder) texts(n *yaml.Node) map[string]string {
+	if n.Kind != yaml.MappingNode {
+		d.errorf(n, "expected a mapping, got %s", kindName(n))
+		return nil
+	}
+	texts := map[string]string{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if _, ok := texts[key.Value]; ok {
+			d.errorf(key, "duplicate locale %q", key.Value)
+			continue
+		}
+		texts[key.Value] = d.str(value)
+	}
+	return texts
+}
+
+func (d *decoder) seq(n *yaml.Node) []*yaml.Node {
+	if n == nil {
+		return nil
+	}
+	if n.Kind != yaml.SequenceNode {
+		d.errorf(n, "expected a list, got %s", kindName(n))
+		return nil
+	}
+	return n.Content
+}
+
+func (d *decoder) uint(n *yaml.Node, maxValue uint64) uint64 {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+		d.errorf(n, "expected an integer, got %s", kindName(n))
+		return 0
+	}
+	v, err := strconv.ParseUint(n.Value, 0, 64)
+	if err != nil || v > maxValue {
+		d.errorf(n, "value %s out of range 0-%d", n.Value, maxValue)
+		return 0
+	}
+	return v
+}
+
+func (d *decoder) str(n *yaml.Node) string {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+		d.errorf(n, "expected a string, got %s", kindName(n))
+		return ""
+	}
+	return n.Value
+}
+
+func kindName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.ScalarNode:
+		return strings.TrimPrefix(n.Tag, "!!")
+	}
+	return "an unsupported node"
+}
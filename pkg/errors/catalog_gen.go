@@ -0,0 +1,536 @@
+// Code generated by treegen from pkg/catalog/errcodes.yaml. DO NOT EDIT.
+
+package errors
+
+// TinyCodeValues holds the error types of the tiny format. Changes made once codes
+// are in use take effect through ReplaceTrees.
+var TinyCodeValues = []TinyErrorInfo{
+	{
+		Value:       0,
+		Name:        "unknown",
+		Description: "Unknown error",
+	},
+	{
+		Value:       1,
+		Name:        "validation",
+		Description: "Validation error",
+	},
+	{
+		Value:       2,
+		Name:        "not_found",
+		Description: "Resource not found",
+	},
+	{
+		Value:       3,
+		Name:        "unauthorized",
+		Description: "Unauthorized access",
+	},
+	{
+		Value:       4,
+		Name:        "bad_request",
+		Description: "Bad request",
+	},
+	{
+		Value:       1295,
+		Name:        "max",
+		Description: "Maximum error value (ZZ)",
+	},
+}
+
+// SimpleCodeTree holds the classes and error types of the simple format. Changes made once codes
+// are in use take effect through ReplaceTrees.
+var SimpleCodeTree = []SimpleClassInfo{
+	{
+		Value:       0,
+		Name:        "unknown",
+		Description: "Unknown",
+		ErrorTypes: []SimpleErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown API error",
+			},
+		},
+	},
+	{
+		Value:       1,
+		Name:        "api",
+		Description: "API related errors",
+		ErrorTypes: []SimpleErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown API error",
+			},
+			{
+				Value:       1,
+				Name:        "validation_error",
+				Description: "API validation error",
+			},
+			{
+				Value:       2,
+				Name:        "authorization_error",
+				Description: "API authorization error",
+			},
+		},
+	},
+	{
+		Value:       2,
+		Name:        "jobs",
+		Description: "Background job errors",
+		ErrorTypes: []SimpleErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown job error",
+			},
+			{
+				Value:       1,
+				Name:        "database_query",
+				Description: "Database query error in job",
+			},
+			{
+				Value:       2,
+				Name:        "timeout",
+				Description: "Job execution timeout",
+			},
+		},
+	},
+	{
+		Value:       255,
+		Name:        "max",
+		Description: "Example max value",
+		ErrorTypes: []SimpleErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown max class error",
+			},
+			{
+				Value:       255,
+				Name:        "max",
+				Description: "Max error type number",
+			},
+		},
+	},
+}
+
+// Simple511CodeTree holds the classes and error types of the simple511 format. Changes made once codes
+// are in use take effect through ReplaceTrees.
+var Simple511CodeTree = []Simple5ClassInfo{
+	{
+		Value:       0,
+		Name:        "unknown",
+		Description: "Unknown error class",
+		ErrorTypes: []Simple11ErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown error",
+			},
+		},
+	},
+	{
+		Value:       1,
+		Name:        "http",
+		Description: "HTTP-related errors",
+		ErrorTypes: []Simple11ErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown HTTP error",
+			},
+			{
+				Value:       1,
+				Name:        "bad_request",
+				Description: "Bad request error (400)",
+			},
+			{
+				Value:       2,
+				Name:        "unauthorized",
+				Description: "Unauthorized error (401)",
+			},
+			{
+				Value:       3,
+				Name:        "forbidden",
+				Description: "Forbidden error (403)",
+			},
+			{
+				Value:       4,
+				Name:        "not_found",
+				Description: "Not found error (404)",
+			},
+		},
+	},
+	{
+		Value:       31,
+		Name:        "max",
+		Description: "Maximum class value example",
+		ErrorTypes: []Simple11ErrorInfo{
+			{
+				Value:       0,
+				Name:        "unknown",
+				Description: "Unknown max class error",
+			},
+			{
+				Value:       2047,
+				Name:        "max",
+				Description: "Maximum error type value",
+			},
+		},
+	},
+}
+
+// CodeTree holds the apps, components, sub-components and error types of the app_component format. Changes made once codes
+// are in use take effect through ReplaceTrees.
+var CodeTree = []AppInfo{
+	{
+		Value:       1,
+		Name:        "backend",
+		Description: "Backend application services",
+		Components: []ComponentInfo{
+			{
+				Value:       1,
+				Name:        "handler",
+				Description: "Request handler services",
+				SubComponents: []SubComponentInfo{
+					{
+						Value:       0,
+						Name:        "unknown",
+						Description: "Unknown handler component",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       0,
+								Name:        "unknown",
+								Description: "Unknown handler error",
+							},
+						},
+					},
+					{
+						Value:       1,
+						Name:        "users",
+						Description: "User management handler",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "validation_error",
+								Description: "Input validation failed for user operation",
+							},
+							{
+								Value:       2,
+								Name:        "authorization_error",
+								Description: "User lacks required permissions for operation",
+							},
+						},
+					},
+					{
+						Value:       2,
+						Name:        "records",
+						Description: "Record management handler",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "validation_error",
+								Description: "Input validation failed for record operation",
+							},
+							{
+								Value:       2,
+								Name:        "authorization_error",
+								Description: "User lacks required permissions for record operation",
+							},
+						},
+					},
+					{
+						Value:       3,
+						Name:        "analytics",
+						Description: "Analytics data handler",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "validation_error",
+								Description: "Input validation failed for analytics operation",
+							},
+							{
+								Value:       2,
+								Name:        "authorization_error",
+								Description: "User lacks required permissions for analytics operation",
+							},
+						},
+					},
+				},
+			},
+			{
+				Value:       2,
+				Name:        "job",
+				Description: "Background job processor",
+				SubComponents: []SubComponentInfo{
+					{
+						Value:       0,
+						Name:        "unknown",
+						Description: "Unknown job component",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       0,
+								Name:        "unknown",
+								Description: "Unknown job error",
+							},
+						},
+					},
+					{
+						Value:       1,
+						Name:        "sync",
+						Description: "Data synchronization job",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "database_error",
+								Description: "Database operation failed during sync",
+							},
+							{
+								Value:       2,
+								Name:        "external_api_error",
+								Description: "External API call failed during sync",
+							},
+							{
+								Value:       3,
+								Name:        "timeout",
+								Description: "Operation timed out during sync",
+							},
+						},
+					},
+					{
+						Value:       2,
+						Name:        "analytics",
+						Description: "Analytics processing job",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "database_error",
+								Description: "Database operation failed during analytics processing",
+							},
+							{
+								Value:       2,
+								Name:        "external_api_error",
+								Description: "External API call failed during analytics processing",
+							},
+							{
+								Value:       3,
+								Name:        "timeout",
+								Description: "Operation timed out during analytics processing",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Value:       2,
+		Name:        "frontend",
+		Description: "Frontend application",
+		Components: []ComponentInfo{
+			{
+				Value:       1,
+				Name:        "ui",
+				Description: "User interface components",
+				SubComponents: []SubComponentInfo{
+					{
+						Value:       0,
+						Name:        "unknown",
+						Description: "Unknown UI component",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       0,
+								Name:        "unknown",
+								Description: "Unknown UI error",
+							},
+						},
+					},
+					{
+						Value:       1,
+						Name:        "forms",
+						Description: "Form handling and validation",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "validation_error",
+								Description: "Form validation failed",
+							},
+							{
+								Value:       2,
+								Name:        "submission_error",
+								Description: "Form submission failed",
+							},
+						},
+					},
+					{
+						Value:       2,
+						Name:        "routing",
+						Description: "Client-side routing",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "not_found",
+								Description: "Route not found",
+							},
+							{
+								Value:       2,
+								Name:        "unauthorized",
+								Description: "Route access unauthorized",
+							},
+						},
+					},
+				},
+			},
+			{
+				Value:       2,
+				Name:        "state",
+				Description: "State management",
+				SubComponents: []SubComponentInfo{
+					{
+						Value:       0,
+						Name:        "unknown",
+						Description: "Unknown state management error",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       0,
+								Name:        "unknown",
+								Description: "Unknown state error",
+							},
+						},
+					},
+					{
+						Value:       1,
+						Name:        "store",
+						Description: "State store operations",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "update_failed",
+								Description: "State update operation failed",
+							},
+							{
+								Value:       2,
+								Name:        "invalid_action",
+								Description: "Invalid state action dispatched",
+							},
+						},
+					},
+					{
+						Value:       2,
+						Name:        "persistence",
+						Description: "State persistence",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "storage_error",
+								Description: "Local storage operation failed",
+							},
+							{
+								Value:       2,
+								Name:        "sync_error",
+								Description: "State synchronization failed",
+							},
+						},
+					},
+				},
+			},
+			{
+				Value:       3,
+				Name:        "api",
+				Description: "API client",
+				SubComponents: []SubComponentInfo{
+					{
+						Value:       0,
+						Name:        "unknown",
+						Description: "Unknown API client error",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       0,
+								Name:        "unknown",
+								Description: "Unknown API error",
+							},
+						},
+					},
+					{
+						Value:       1,
+						Name:        "request",
+						Description: "API request handling",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "network_error",
+								Description: "Network request failed",
+							},
+							{
+								Value:       2,
+								Name:        "timeout",
+								Description: "Request timed out",
+							},
+							{
+								Value:       3,
+								Name:        "invalid_response",
+								Description: "Invalid response received",
+							},
+						},
+					},
+					{
+						Value:       2,
+						Name:        "cache",
+						Description: "API response caching",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       1,
+								Name:        "cache_miss",
+								Description: "Cache miss error",
+							},
+							{
+								Value:       2,
+								Name:        "cache_invalid",
+								Description: "Cache invalidation error",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Value:       15,
+		Name:        "max",
+		Description: "Maximum value example app",
+		Components: []ComponentInfo{
+			{
+				Value:       63,
+				Name:        "max_component",
+				Description: "Maximum value component",
+				SubComponents: []SubComponentInfo{
+					{
+						Value:       0,
+						Name:        "unknown",
+						Description: "Unknown max component",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       0,
+								Name:        "unknown",
+								Description: "Unknown max component error",
+							},
+						},
+					},
+					{
+						Value:       63,
+						Name:        "max_subcomponent",
+						Description: "Maximum value sub-component",
+						ErrorTypes: []ErrorInfo{
+							{
+								Value:       255,
+								Name:        "max_error",
+								Description: "Maximum possible error code value",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
@@ -1,6 +1,10 @@
 package errors
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+)
 
 // Code fields: [App][Component][SubComponent][ErrType]
 // Tree-like structure
@@ -43,6 +47,10 @@ type ErrorInfo struct {
 	Value       ErrorCode
 	Name        string
 	Description string
+	HTTPStatus  int // 0 derives the status from the name
+	Localization
+	Lifecycle
+	Metadata
 }
 
 type SubComponentInfo struct {
@@ -50,6 +58,8 @@ type SubComponentInfo struct {
 	Name        string
 	Description string
 	ErrorTypes  []ErrorInfo
+	Lifecycle
+	Metadata
 }
 
 type ComponentInfo struct {
@@ -57,6 +67,8 @@ type ComponentInfo struct {
 	Name          string
 	Description   string
 	SubComponents []SubComponentInfo
+	Lifecycle
+	Metadata
 }
 
 type AppInfo struct {
@@ -64,516 +76,204 @@ type AppInfo struct {
 	Name        string
 	Description string
 	Components  []ComponentInfo
+	Lifecycle
+	Metadata
 }
 
-var CodeTree = []AppInfo{
-	{
-		Value:       1,
-		Name:        "backend",
-		Description: "Backend application services",
-		Components: []ComponentInfo{
-			{
-				Value:       1,
-				Name:        "handler",
-				Description: "Request handler services",
-				SubComponents: []SubComponentInfo{
-					{
-						Value:       0,
-						Name:        "unknown",
-						Description: "Unknown handler component",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       0,
-								Name:        "unknown",
-								Description: "Unknown handler error",
-							},
-						},
-					},
-					{
-						Value:       1,
-						Name:        "users",
-						Description: "User management handler",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "validation_error",
-								Description: "Input validation failed for user operation",
-							},
-							{
-								Value:       2,
-								Name:        "authorization_error",
-								Description: "User lacks required permissions for operation",
-							},
-						},
-					},
-					{
-						Value:       2,
-						Name:        "records",
-						Description: "Record management handler",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "validation_error",
-								Description: "Input validation failed for record operation",
-							},
-							{
-								Value:       2,
-								Name:        "authorization_error",
-								Description: "User lacks required permissions for record operation",
-							},
-						},
-					},
-					{
-						Value:       3,
-						Name:        "analytics",
-						Description: "Analytics data handler",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "validation_error",
-								Description: "Input validation failed for analytics operation",
-							},
-							{
-								Value:       2,
-								Name:        "authorization_error",
-								Description: "User lacks required permissions for analytics operation",
-							},
-						},
-					},
-				},
-			},
-			{
-				Value:       2,
-				Name:        "job",
-				Description: "Background job processor",
-				SubComponents: []SubComponentInfo{
-					{
-						Value:       0,
-						Name:        "unknown",
-						Description: "Unknown job component",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       0,
-								Name:        "unknown",
-								Description: "Unknown job error",
-							},
-						},
-					},
-					{
-						Value:       1,
-						Name:        "sync",
-						Description: "Data synchronization job",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "database_error",
-								Description: "Database operation failed during sync",
-							},
-							{
-								Value:       2,
-								Name:        "external_api_error",
-								Description: "External API call failed during sync",
-							},
-							{
-								Value:       3,
-								Name:        "timeout",
-								Description: "Operation timed out during sync",
-							},
-						},
-					},
-					{
-						Value:       2,
-						Name:        "analytics",
-						Description: "Analytics processing job",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "database_error",
-								Description: "Database operation failed during analytics processing",
-							},
-							{
-								Value:       2,
-								Name:        "external_api_error",
-								Description: "External API call failed during analytics processing",
-							},
-							{
-								Value:       3,
-								Name:        "timeout",
-								Description: "Operation timed out during analytics processing",
-							},
-						},
-					},
-				},
-			},
-		},
+var appComponentLayout = Layout{
+	Type:  CodeTypeAppComponent,
+	Width: 5, // 5 chars for 24 bits of data
+	Fields: []LayoutField{
+		{Name: "App", Bits: 4, Description: "Identifies the application"},
+		{Name: "Component", Bits: 6, Description: "Identifies the major component"},
+		{Name: "SubComponent", Bits: 6, Description: "Identifies the specific sub-component"},
+		{Name: "ErrorType", Bits: 8, Description: "Identifies the specific error"},
 	},
-	{
-		Value:       2,
-		Name:        "frontend",
-		Description: "Frontend application",
-		Components: []ComponentInfo{
-			{
-				Value:       1,
-				Name:        "ui",
-				Description: "User interface components",
-				SubComponents: []SubComponentInfo{
-					{
-						Value:       0,
-						Name:        "unknown",
-						Description: "Unknown UI component",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       0,
-								Name:        "unknown",
-								Description: "Unknown UI error",
-							},
-						},
-					},
-					{
-						Value:       1,
-						Name:        "forms",
-						Description: "Form handling and validation",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "validation_error",
-								Description: "Form validation failed",
-							},
-							{
-								Value:       2,
-								Name:        "submission_error",
-								Description: "Form submission failed",
-							},
-						},
-					},
-					{
-						Value:       2,
-						Name:        "routing",
-						Description: "Client-side routing",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "not_found",
-								Description: "Route not found",
-							},
-							{
-								Value:       2,
-								Name:        "unauthorized",
-								Description: "Route access unauthorized",
-							},
-						},
-					},
-				},
-			},
-			{
-				Value:       2,
-				Name:        "state",
-				Description: "State management",
-				SubComponents: []SubComponentInfo{
-					{
-						Value:       0,
-						Name:        "unknown",
-						Description: "Unknown state management error",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       0,
-								Name:        "unknown",
-								Description: "Unknown state error",
-							},
-						},
-					},
-					{
-						Value:       1,
-						Name:        "store",
-						Description: "State store operations",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "update_failed",
-								Description: "State update operation failed",
-							},
-							{
-								Value:       2,
-								Name:        "invalid_action",
-								Description: "Invalid state action dispatched",
-							},
-						},
-					},
-					{
-						Value:       2,
-						Name:        "persistence",
-						Description: "State persistence",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "storage_error",
-								Description: "Local storage operation failed",
-							},
-							{
-								Value:       2,
-								Name:        "sync_error",
-								Description: "State synchronization failed",
-							},
-						},
-					},
-				},
-			},
-			{
-				Value:       3,
-				Name:        "api",
-				Description: "API client",
-				SubComponents: []SubComponentInfo{
-					{
-						Value:       0,
-						Name:        "unknown",
-						Description: "Unknown API client error",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       0,
-								Name:        "unknown",
-								Description: "Unknown API error",
-							},
-						},
-					},
-					{
-						Value:       1,
-						Name:        "request",
-						Description: "API request handling",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "network_error",
-								Description: "Network request failed",
-							},
-							{
-								Value:       2,
-								Name:        "timeout",
-								Description: "Request timed out",
-							},
-							{
-								Value:       3,
-								Name:        "invalid_response",
-								Description: "Invalid response received",
-							},
-						},
-					},
-					{
-						Value:       2,
-						Name:        "cache",
-						Description: "API response caching",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       1,
-								Name:        "cache_miss",
-								Description: "Cache miss error",
-							},
-							{
-								Value:       2,
-								Name:        "cache_invalid",
-								Description: "Cache invalidation error",
-							},
-						},
-					},
-				},
-			},
+}
+
+func init() {
+	MustRegister(CodeTypeAppComponent, Format{
+		Name:      "app_component",
+		Prototype: AppComponentErrorCode{},
+		Layout:    &appComponentLayout,
+		Decode: func(code string) (ErrorType, error) {
+			return DecodeAppComponentErrorCode(code)
 		},
-	},
-	{
-		Value:       15, // Max 4-bit value (0xF)
-		Name:        "max",
-		Description: "Maximum value example app",
-		Components: []ComponentInfo{
-			{
-				Value:       63, // Max 6-bit value (0x3F)
-				Name:        "max_component",
-				Description: "Maximum value component",
-				SubComponents: []SubComponentInfo{
-					{
-						Value:       63, // Max 6-bit value (0x3F)
-						Name:        "max_subcomponent",
-						Description: "Maximum value sub-component",
-						ErrorTypes: []ErrorInfo{
-							{
-								Value:       255, // Max 8-bit value (0xFF)
-								Name:        "max_error",
-								Description: "Maximum possible error code value",
-							},
-						},
-					},
-				},
-			},
+		Validate: validateAppComponent,
+		Lookup:   lookupAppComponent,
+		ParseName: func(name string) (ErrorType, error) {
+			code, _, err := ParseAppComponentName(name)
+			return code, err
 		},
-	},
+	})
 }
 
 func (AppComponentErrorCode) GetType() CodeType {
 	return CodeTypeAppComponent
 }
 
+// Encode returns the code string. Values that do not fit into their field
+// are masked and alias other codes; EncodeChecked rejects them.
 func (e AppComponentErrorCode) Encode() string {
-	// Pack the bits into a 24-bit number
-	packed := uint32(e.App&0x0F)<<20 | // 4 bits App
-		uint32(e.Component&0x3F)<<14 | // 6 bits Component
-		uint32(e.SubComponent&0x3F)<<8 | // 6 bits SubComponent
-		uint32(e.ErrType&0xFF) // 8 bits ErrorType
-
-	// Convert type and data to base36
-	typeStr := toBase36(uint32(e.GetType()), 1) // 1 char for type (0-36)
-	dataStr := toBase36(packed, 5)              // 5 chars for 24 bits of data
+	return appComponentLayout.EncodeMasked(uint32(e.App), uint32(e.Component), uint32(e.SubComponent), uint32(e.ErrType))
+}
 
-	return fmt.Sprintf("E%s%s", typeStr, dataStr)
+// EncodeChecked is like Encode but returns an ErrOverflow error for values
+// that do not fit into their field
+func (e AppComponentErrorCode) EncodeChecked() (string, error) {
+	return appComponentLayout.Encode(uint32(e.App), uint32(e.Component), uint32(e.SubComponent), uint32(e.ErrType))
 }
 
 func DecodeAppComponentErrorCode(code string) (AppComponentErrorCode, error) {
-	if len(code) != 7 || code[0] != 'E' { // Format: E<type><data>
-		return AppComponentErrorCode{}, fmt.Errorf("invalid code format: %s", code)
-	}
-
-	// Parse type
-	typeVal := CodeType(fromBase36(code[1:2]))
-	if typeVal != CodeTypeAppComponent {
-		return AppComponentErrorCode{}, fmt.Errorf("invalid code type: %d", typeVal)
+	values, err := appComponentLayout.Decode(code)
+	if err != nil {
+		return AppComponentErrorCode{}, err
 	}
 
-	// Parse data
-	packed := fromBase36(code[2:])
-
-	// Extract fields using bit masks
 	return AppComponentErrorCode{
-		App:          AppCode((packed >> 20) & 0x0F),         // 4 bits
-		Component:    ComponentCode((packed >> 14) & 0x3F),   // 6 bits
-		SubComponent: SubComponentCode((packed >> 8) & 0x3F), // 6 bits
-		ErrType:      ErrorCode(packed & 0xFF),               // 8 bits
+		App:          AppCode(values[0]),
+		Component:    ComponentCode(values[1]),
+		SubComponent: SubComponentCode(values[2]),
+		ErrType:      ErrorCode(values[3]),
 	}, nil
 }
 
+// ParseAppComponentName returns the code named like
+// "backend.handler.users.validation_error" and its catalog entry
+func ParseAppComponentName(name string) (AppComponentErrorCode, Permutation, error) {
+	p, err := newNameParser("app_component", appComponentLayout, name)
+	if err != nil {
+		return AppComponentErrorCode{}, Permutation{}, err
+	}
+	app, err := findName(p, 0, currentTrees().app, func(a AppInfo) string { return a.Name })
+	if err != nil {
+		return AppComponentErrorCode{}, Permutation{}, err
+	}
+	comp, err := findName(p, 1, app.Components, func(c ComponentInfo) string { return c.Name })
+	if err != nil {
+		return AppComponentErrorCode{}, Permutation{}, err
+	}
+	subComp, err := findName(p, 2, comp.SubComponents, func(s SubComponentInfo) string { return s.Name })
+	if err != nil {
+		return AppComponentErrorCode{}, Permutation{}, err
+	}
+	errType, err := findName(p, 3, subComp.ErrorTypes, func(e ErrorInfo) string { return e.Name })
+	if err != nil {
+		return AppComponentErrorCode{}, Permutation{}, err
+	}
+
+	code := AppComponentErrorCode{
+		App:          app.Value,
+		Component:    comp.Value,
+		SubComponent: subComp.Value,
+		ErrType:      errType.Value,
+	}
+	perm, _ := Lookup(code)
+	return code, perm, nil
+}
+
 // String returns a human-readable representation of the error code
 func (e AppComponentErrorCode) String() string {
-	for _, app := range CodeTree {
-		if app.Value != e.App {
-			continue
-		}
-		for _, comp := range app.Components {
-			if comp.Value != e.Component {
-				continue
-			}
-			for _, subComp := range comp.SubComponents {
-				if subComp.Value != e.SubComponent {
-					continue
-				}
-				for _, errType := range subComp.ErrorTypes {
-					if errType.Value != e.ErrType {
-						continue
-					}
-					return fmt.Sprintf("%s.%s.%s.%s",
-						app.Name, comp.Name, subComp.Name, errType.Name)
-				}
-			}
-		}
+	entry, ok := appComponentIndex.get().lookup(appComponentLayout,
+		uint32(e.App), uint32(e.Component), uint32(e.SubComponent), uint32(e.ErrType))
+	if ok {
+		return entry.name
 	}
 	return "invalid"
 }
 
+// Error implements the error interface so the code can be used as an
+// errors.Is target. As fmt prefers Error over String, %v and %s print the
+// encoded code and the path, e.g.
+// "EA0MTXD backend.handler.users.validation_error"; use String for the path
+// alone.
+func (e AppComponentErrorCode) Error() string {
+	return codeMessage(e)
+}
+
+// MarshalText and the other encoding methods store the code as its encoded
+// string
+func (e AppComponentErrorCode) MarshalText() ([]byte, error) {
+	encoded, err := e.EncodeChecked()
+	return []byte(encoded), err
+}
+
+func (e *AppComponentErrorCode) UnmarshalText(text []byte) error {
+	return decodeInto(e, string(text), DecodeAppComponentErrorCode)
+}
+
+func (e AppComponentErrorCode) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(e)
+}
+
+func (e *AppComponentErrorCode) UnmarshalJSON(data []byte) error {
+	return unmarshalCodeJSON(e, data, DecodeAppComponentErrorCode)
+}
+
+func (e AppComponentErrorCode) Value() (driver.Value, error) {
+	return codeValue(e)
+}
+
+func (e *AppComponentErrorCode) Scan(src any) error {
+	return scanCode(e, src, DecodeAppComponentErrorCode)
+}
+
+// LogValue logs the code as a group of its code, format, path and fields
+func (e AppComponentErrorCode) LogValue() slog.Value {
+	return slog.GroupValue(codeAttrs(e)...)
+}
+
 func (AppComponentErrorCode) GetPrefix() string {
 	return "E"
 }
 
 func (AppComponentErrorCode) GetDocSection() DocSection {
 	return DocSection{
-		Title: "App Component Format",
-		Description: `Each error code is composed of 24 bits of data encoded as follows:
-- App (4 bits): Identifies the application (allows up to 16 apps)
-- Component (6 bits): Identifies the major component (allows up to 64 components per app)
-- SubComponent (6 bits): Identifies the specific sub-component (allows up to 64 sub-components per component)
-- ErrorType (8 bits): Identifies the specific error (allows up to 256 error types per sub-component)
-
-The format provides:
-- Up to 16 different applications
-- Up to 64 different components per application
-- Up to 64 different sub-components per component
-- Up to 256 different error types per sub-component
-- Total of 16,777,216 possible unique error codes (16 * 64 * 64 * 256)
-
-The code is encoded as E<type><data> where:
-- E: Fixed prefix
-- type: 1 base-36 character encoding the type (2)
-- data: 5 base-36 characters encoding the packed 24 bits
-
-Bit layout before encoding:
-` + "```" + `
-[AAAACCCC][CCSSSSSS][EEEEEEEE]
-A: App bits
-C: Component bits
-S: SubComponent bits
-E: ErrorType bits
-` + "```" + ``,
-		Headers: []string{"Code", "App.Component.SubComponent.Type", "Description"},
+		Title:       "App Component Format",
+		Description: appComponentLayout.Describe(),
+		Headers:     []string{"Code", "App.Component.SubComponent.Type", "Description"},
 	}
 }
+
 func (AppComponentErrorCode) GetFieldInfo() []FieldInfo {
-	return []FieldInfo{
-		{
-			Name:        "App",
-			Bits:        4,
-			Description: "Identifies the application (0-15)",
-			Values:      "backend(1), frontend(2)",
-		},
-		{
-			Name:        "Component",
-			Bits:        6,
-			Description: "Identifies the major component (0-63)",
-			Values:      "handler(1), job(2), ui(1), state(2), api(3)",
-		},
-		{
-			Name:        "SubComponent",
-			Bits:        6,
-			Description: "Identifies the specific sub-component (0-63)",
-			Values:      "unknown(0), users(1), records(2), analytics(3), forms(1), routing(2), store(1), persistence(2), request(1), cache(2)",
-		},
-		{
-			Name:        "ErrorType",
-			Bits:        8,
-			Description: "Identifies the specific error (0-255)",
-			Values:      "unknown(0), validation_error(1), authorization_error(2), database_error(1), external_api_error(2), timeout(3), etc",
-		},
+	var comps []ComponentInfo
+	var subComps []SubComponentInfo
+	var errTypes []ErrorInfo
+	tree := currentTrees().app
+	for _, app := range tree {
+		comps = append(comps, app.Components...)
+		for _, comp := range app.Components {
+			subComps = append(subComps, comp.SubComponents...)
+			for _, subComp := range comp.SubComponents {
+				errTypes = append(errTypes, subComp.ErrorTypes...)
+			}
+		}
 	}
+
+	return appComponentLayout.FieldInfo(
+		valueList(tree, func(a AppInfo) (string, uint32) { return a.Name, uint32(a.Value) }),
+		valueList(comps, func(c ComponentInfo) (string, uint32) { return c.Name, uint32(c.Value) }),
+		valueList(subComps, func(s SubComponentInfo) (string, uint32) { return s.Name, uint32(s.Value) }),
+		valueList(errTypes, func(e ErrorInfo) (string, uint32) { return e.Name, uint32(e.Value) }),
+	)
 }
 
-// Update the GetPermutations method to use new encoding
 func (AppComponentErrorCode) GetPermutations() []Permutation {
-	var perms []Permutation
+	return appComponentIndex.get().permutations()
+}
+
+var appComponentIndex = lazyIndex[AppInfo]{tree: func(s *treeSet) []AppInfo { return s.app }, build: buildAppComponentIndex}
 
-	for _, app := range CodeTree {
+func buildAppComponentIndex(tree []AppInfo) *catalogIndex {
+	idx := &catalogIndex{}
+	for _, app := range tree {
 		for _, comp := range app.Components {
 			for _, subComp := range comp.SubComponents {
 				for _, errType := range subComp.ErrorTypes {
-					ace := AppComponentErrorCode{
-						App:          app.Value,
-						Component:    comp.Value,
-						SubComponent: subComp.Value,
-						ErrType:      errType.Value,
-					}
-
-					code := ace.Encode()
-
-					// Validate by decoding and comparing
-					decoded, err := DecodeAppComponentErrorCode(code)
-					if err != nil {
-						panic(fmt.Sprintf("failed to decode app component code: %v", err))
-					}
-					if decoded.App != ace.App ||
-						decoded.Component != ace.Component ||
-						decoded.SubComponent != ace.SubComponent ||
-						decoded.ErrType != ace.ErrType {
-						panic(fmt.Sprintf("decode mismatch: got %v, want %v", decoded, ace))
-					}
-
 					path := fmt.Sprintf("%s.%s.%s.%s",
 						app.Name, comp.Name, subComp.Name, errType.Name)
 
-					perms = append(perms, Permutation{
-						Type: CodeTypeAppComponent,
-						Code: code,
+					idx.add(appComponentLayout, path, Permutation{
 						Fields: map[string]string{
 							"App":          app.Name,
 							"Component":    comp.Name,
@@ -582,14 +282,26 @@ func (AppComponentErrorCode) GetPermutations() []Permutation {
 							"Description":  errType.Description,
 						},
 						TableFields: []string{
-							code,
 							path,
 							errType.Description,
 						},
-					})
+						HTTPStatus:   errType.HTTPStatus,
+						Localization: errType.Localization,
+						Lifecycle:    inheritLifecycle(errType.Lifecycle, subComp.Lifecycle, comp.Lifecycle, app.Lifecycle),
+						Metadata:     inheritMetadata(errType.Metadata, subComp.Metadata, comp.Metadata, app.Metadata),
+					}, uint32(app.Value), uint32(comp.Value), uint32(subComp.Value), uint32(errType.Value))
 				}
 			}
 		}
 	}
-	return perms
+	return idx
+}
+
+func lookupAppComponent(code ErrorType) (Permutation, bool) {
+	c, ok := code.(AppComponentErrorCode)
+	if !ok {
+		return Permutation{}, false
+	}
+	return appComponentIndex.get().permutation(appComponentLayout,
+		uint32(c.App), uint32(c.Component), uint32(c.SubComponent), uint32(c.ErrType))
 }
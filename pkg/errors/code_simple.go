@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"log/slog"
+)
 
 // Code fields: [Class][ErrType]
 // Tree-like structure
@@ -25,6 +28,10 @@ type SimpleErrorInfo struct {
 	Value       SimpleErrorCode
 	Name        string
 	Description string
+	HTTPStatus  int // 0 derives the status from the name
+	Localization
+	Lifecycle
+	Metadata
 }
 
 type SimpleClassInfo struct {
@@ -32,216 +39,194 @@ type SimpleClassInfo struct {
 	Name        string
 	Description string
 	ErrorTypes  []SimpleErrorInfo
+	Lifecycle
+	Metadata
 }
 
-var SimpleCodeTree = []SimpleClassInfo{
-	{
-		Value:       0,
-		Name:        "unknown",
-		Description: "Unknown",
-		ErrorTypes: []SimpleErrorInfo{
-			{
-				Value:       0,
-				Name:        "unknown",
-				Description: "Unknown API error",
-			},
-		},
-	},
-	{
-		Value:       1,
-		Name:        "api",
-		Description: "API related errors",
-		ErrorTypes: []SimpleErrorInfo{
-			{
-				Value:       0,
-				Name:        "unknown",
-				Description: "Unknown API error",
-			},
-			{
-				Value:       1,
-				Name:        "validation_error",
-				Description: "API validation error",
-			},
-			{
-				Value:       2,
-				Name:        "authorization_error",
-				Description: "API authorization error",
-			},
-		},
+var simpleLayout = Layout{
+	Type:  CodeTypeSimple,
+	Width: 4, // 4 chars for 16 bits of data
+	Fields: []LayoutField{
+		{Name: "Class", Bits: 8, Description: "Identifies the error class"},
+		{Name: "ErrorType", Bits: 8, Description: "Identifies the specific error"},
 	},
-	{
-		Value:       2,
-		Name:        "jobs",
-		Description: "Background job errors",
-		ErrorTypes: []SimpleErrorInfo{
-			{
-				Value:       0,
-				Name:        "unknown",
-				Description: "Unknown job error",
-			},
-			{
-				Value:       1,
-				Name:        "database_query",
-				Description: "Database query error in job",
-			},
-			{
-				Value:       2,
-				Name:        "timeout",
-				Description: "Job execution timeout",
-			},
+}
+
+func init() {
+	MustRegister(CodeTypeSimple, Format{
+		Name:      "simple",
+		Prototype: SimpleCode{},
+		Layout:    &simpleLayout,
+		Decode: func(code string) (ErrorType, error) {
+			return DecodeSimpleCode(code)
 		},
-	},
-	{
-		Value:       255,
-		Name:        "max",
-		Description: "Example max value",
-		ErrorTypes: []SimpleErrorInfo{
-			{
-				Value:       255,
-				Name:        "max",
-				Description: "Max error type number",
-			},
+		Validate: validateSimple,
+		Lookup:   lookupSimple,
+		ParseName: func(name string) (ErrorType, error) {
+			code, _, err := ParseSimpleName(name)
+			return code, err
 		},
-	},
+	})
 }
 
 func (SimpleCode) GetType() CodeType {
 	return CodeTypeSimple
 }
 
+// Encode returns the code string. Values that do not fit into their field
+// are masked and alias other codes; EncodeChecked rejects them.
 func (e SimpleCode) Encode() string {
-	// Pack into 16 bits:
-	// - 8 bits for Class
-	// - 8 bits for ErrorType
-	packed := uint32(e.Class)<<8 | uint32(e.ErrType)
-
-	// Convert type and data to base36
-	typeStr := toBase36(uint32(e.GetType()), 1) // 1 char for type
-	dataStr := toBase36(packed, 4)              // 4 chars for 16 bits of data (increased from 3)
+	return simpleLayout.EncodeMasked(uint32(e.Class), uint32(e.ErrType))
+}
 
-	return fmt.Sprintf("E%s%s", typeStr, dataStr)
+// EncodeChecked is like Encode but returns an ErrOverflow error for values
+// that do not fit into their field
+func (e SimpleCode) EncodeChecked() (string, error) {
+	return simpleLayout.Encode(uint32(e.Class), uint32(e.ErrType))
 }
 
 func DecodeSimpleCode(code string) (SimpleCode, error) {
-	if len(code) != 6 || code[0] != 'E' { // Format: E<type><data> (length now 6 due to 4 chars data)
-		return SimpleCode{}, fmt.Errorf("invalid code format: %s", code)
+	values, err := simpleLayout.Decode(code)
+	if err != nil {
+		return SimpleCode{}, err
 	}
 
-	// Parse type
-	typeVal := CodeType(fromBase36(code[1:2]))
-	if typeVal != CodeTypeSimple {
-		return SimpleCode{}, fmt.Errorf("invalid code type: %d", typeVal)
-	}
-
-	// Parse data
-	packed := fromBase36(code[2:])
-
 	return SimpleCode{
-		Class:   ClassCode((packed >> 8) & 0xFF),
-		ErrType: SimpleErrorCode(packed & 0xFF),
+		Class:   ClassCode(values[0]),
+		ErrType: SimpleErrorCode(values[1]),
 	}, nil
 }
 
+// ParseSimpleName returns the code named like "api.validation_error" and
+// its catalog entry
+func ParseSimpleName(name string) (SimpleCode, Permutation, error) {
+	p, err := newNameParser("simple", simpleLayout, name)
+	if err != nil {
+		return SimpleCode{}, Permutation{}, err
+	}
+	class, err := findName(p, 0, currentTrees().simple, func(c SimpleClassInfo) string { return c.Name })
+	if err != nil {
+		return SimpleCode{}, Permutation{}, err
+	}
+	errType, err := findName(p, 1, class.ErrorTypes, func(e SimpleErrorInfo) string { return e.Name })
+	if err != nil {
+		return SimpleCode{}, Permutation{}, err
+	}
+
+	code := SimpleCode{Class: class.Value, ErrType: errType.Value}
+	perm, _ := Lookup(code)
+	return code, perm, nil
+}
+
 // String returns a human-readable representation of the error code
 func (e SimpleCode) String() string {
-	for _, class := range SimpleCodeTree {
-		if class.Value != e.Class {
-			continue
-		}
-		for _, errType := range class.ErrorTypes {
-			if errType.Value != e.ErrType {
-				continue
-			}
-			return fmt.Sprintf("%s.%s", class.Name, errType.Name)
-		}
+	if entry, ok := simpleIndex.get().lookup(simpleLayout, uint32(e.Class), uint32(e.ErrType)); ok {
+		return entry.name
 	}
 	return "invalid"
 }
 
+// Error implements the error interface so the code can be used as an
+// errors.Is target. As fmt prefers Error over String, %v and %s print the
+// encoded code and the path, e.g. "E10075 api.validation_error"; use String
+// for the path alone.
+func (e SimpleCode) Error() string {
+	return codeMessage(e)
+}
+
+// MarshalText and the other encoding methods store the code as its encoded
+// string
+func (e SimpleCode) MarshalText() ([]byte, error) {
+	encoded, err := e.EncodeChecked()
+	return []byte(encoded), err
+}
+
+func (e *SimpleCode) UnmarshalText(text []byte) error {
+	return decodeInto(e, string(text), DecodeSimpleCode)
+}
+
+func (e SimpleCode) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(e)
+}
+
+func (e *SimpleCode) UnmarshalJSON(data []byte) error {
+	return unmarshalCodeJSON(e, data, DecodeSimpleCode)
+}
+
+func (e SimpleCode) Value() (driver.Value, error) {
+	return codeValue(e)
+}
+
+func (e *SimpleCode) Scan(src any) error {
+	return scanCode(e, src, DecodeSimpleCode)
+}
+
+// LogValue logs the code as a group of its code, format, path and fields
+func (e SimpleCode) LogValue() slog.Value {
+	return slog.GroupValue(codeAttrs(e)...)
+}
+
 func (SimpleCode) GetPrefix() string {
 	return "E"
 }
 
 func (SimpleCode) GetFieldInfo() []FieldInfo {
-	return []FieldInfo{
-		{
-			Name:        "Class",
-			Bits:        8,
-			Description: "Identifies the error class (0-255)",
-			Values:      "api(1), jobs(2)",
-		},
-		{
-			Name:        "ErrorType",
-			Bits:        8,
-			Description: "Identifies the specific error (0-255)",
-			Values:      "unknown(0), validation(1), etc",
-		},
+	var errTypes []SimpleErrorInfo
+	tree := currentTrees().simple
+	for _, class := range tree {
+		errTypes = append(errTypes, class.ErrorTypes...)
 	}
+
+	return simpleLayout.FieldInfo(
+		valueList(tree, func(c SimpleClassInfo) (string, uint32) { return c.Name, uint32(c.Value) }),
+		valueList(errTypes, func(e SimpleErrorInfo) (string, uint32) { return e.Name, uint32(e.Value) }),
+	)
 }
 
 func (SimpleCode) GetDocSection() DocSection {
 	return DocSection{
-		Title: "Simple Format",
-		Description: `Each error code is composed of two bytes encoded as follows:
-- Class (8 bits): Identifies the error class (allows up to 256 distinct classes)
-- ErrorType (8 bits): Identifies the specific error (allows up to 256 errors per class)
-
-The format provides:
-- Up to 256 different classes
-- Up to 256 different error types per class
-- Total of 65,536 possible unique error codes
-
-The code is encoded as E<type><data> where:
-- E: Fixed prefix
-- type: 1 character in base-36 encoding the error type
-- data: 4 characters in base-36 encoding the class and error type bits
-
-Bit layout before encoding:
-` + "```" + `
-[CCCCCCCC][EEEEEEEE]
-C: Class bits
-E: ErrorType bits
-` + "```",
-		Headers: []string{"Code", "Class.Type", "Description"},
+		Title:       "Simple Format",
+		Description: simpleLayout.Describe(),
+		Headers:     []string{"Code", "Class.Type", "Description"},
 	}
 }
 
 // GetPermutations returns all possible error code combinations
 func (SimpleCode) GetPermutations() []Permutation {
-	var perms []Permutation
+	return simpleIndex.get().permutations()
+}
+
+var simpleIndex = lazyIndex[SimpleClassInfo]{tree: func(s *treeSet) []SimpleClassInfo { return s.simple }, build: buildSimpleIndex}
 
-	for _, class := range SimpleCodeTree {
+func buildSimpleIndex(tree []SimpleClassInfo) *catalogIndex {
+	idx := &catalogIndex{}
+	for _, class := range tree {
 		for _, errType := range class.ErrorTypes {
-			sc := SimpleCode{
-				Class:   class.Value,
-				ErrType: errType.Value,
-			}
-			code := sc.Encode()
-
-			// Validate by decoding and comparing
-			decoded, err := DecodeSimpleCode(code)
-			if err != nil {
-				panic(fmt.Sprintf("failed to decode simple code: %v", err))
-			}
-			if decoded.Class != sc.Class || decoded.ErrType != sc.ErrType {
-				panic(fmt.Sprintf("decode mismatch: got %v, want %v", decoded, sc))
-			}
-
-			perms = append(perms, Permutation{
-				Type: CodeTypeSimple,
-				Code: code,
+			name := class.Name + "." + errType.Name
+			idx.add(simpleLayout, name, Permutation{
 				Fields: map[string]string{
 					"Class":       class.Name,
 					"ErrorType":   errType.Name,
 					"Description": errType.Description,
 				},
 				TableFields: []string{
-					code,
-					fmt.Sprintf("%s.%s", class.Name, errType.Name),
+					name,
 					errType.Description,
 				},
-			})
+				HTTPStatus:   errType.HTTPStatus,
+				Localization: errType.Localization,
+				Lifecycle:    inheritLifecycle(errType.Lifecycle, class.Lifecycle),
+				Metadata:     inheritMetadata(errType.Metadata, class.Metadata),
+			}, uint32(class.Value), uint32(errType.Value))
 		}
 	}
-	return perms
+	return idx
+}
+
+func lookupSimple(code ErrorType) (Permutation, bool) {
+	c, ok := code.(SimpleCode)
+	if !ok {
+		return Permutation{}, false
+	}
+	return simpleIndex.get().permutation(simpleLayout, uint32(c.Class), uint32(c.ErrType))
 }
@@ -1,10 +1,11 @@
 package errors
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"log/slog"
+)
 
 // Code fields: [Class(5)][ErrType(11)]
-// First byte:  [CCCCCEEE]
-// Second byte: [EEEEEEEE]
 // Allows for 32 classes and 2048 error types per class
 
 type Class5Code uint8
@@ -19,6 +20,10 @@ type Simple11ErrorInfo struct {
 	Value       Simple11ErrorCode
 	Name        string
 	Description string
+	HTTPStatus  int // 0 derives the status from the name
+	Localization
+	Lifecycle
+	Metadata
 }
 
 type Simple5ClassInfo struct {
@@ -26,209 +31,192 @@ type Simple5ClassInfo struct {
 	Name        string
 	Description string
 	ErrorTypes  []Simple11ErrorInfo
+	Lifecycle
+	Metadata
 }
 
-// Example error codes - you can expand this based on your needs
-var Simple511CodeTree = []Simple5ClassInfo{
-	{
-		Value:       0,
-		Name:        "unknown",
-		Description: "Unknown error class",
-		ErrorTypes: []Simple11ErrorInfo{
-			{
-				Value:       0,
-				Name:        "unknown",
-				Description: "Unknown error",
-			},
-		},
+var simple511Layout = Layout{
+	Type:  CodeTypeSimple511,
+	Width: 4, // 4 chars for 16 bits of data
+	Fields: []LayoutField{
+		{Name: "Class", Bits: 5, Description: "Identifies the error class"},
+		{Name: "ErrorType", Bits: 11, Description: "Identifies the specific error"},
 	},
-	{
-		Value:       1,
-		Name:        "http",
-		Description: "HTTP-related errors",
-		ErrorTypes: []Simple11ErrorInfo{
-			{
-				Value:       0,
-				Name:        "unknown",
-				Description: "Unknown HTTP error",
-			},
-			{
-				Value:       1,
-				Name:        "bad_request",
-				Description: "Bad request error (400)",
-			},
-			{
-				Value:       2,
-				Name:        "unauthorized",
-				Description: "Unauthorized error (401)",
-			},
-			{
-				Value:       3,
-				Name:        "forbidden",
-				Description: "Forbidden error (403)",
-			},
-			{
-				Value:       4,
-				Name:        "not_found",
-				Description: "Not found error (404)",
-			},
+}
+
+func init() {
+	MustRegister(CodeTypeSimple511, Format{
+		Name:      "simple511",
+		Prototype: Simple511Code{},
+		Layout:    &simple511Layout,
+		Decode: func(code string) (ErrorType, error) {
+			return DecodeSimple511Code(code)
 		},
-	},
-	{
-		Value:       31,
-		Name:        "max",
-		Description: "Maximum class value example",
-		ErrorTypes: []Simple11ErrorInfo{
-			{
-				Value:       2047,
-				Name:        "max",
-				Description: "Maximum error type value",
-			},
+		Validate: validateSimple511,
+		Lookup:   lookupSimple511,
+		ParseName: func(name string) (ErrorType, error) {
+			code, _, err := ParseSimple511Name(name)
+			return code, err
 		},
-	},
+	})
 }
 
 func (Simple511Code) GetType() CodeType {
-	return CodeType(CodeTypeSimple511)
+	return CodeTypeSimple511
 }
 
+// Encode returns the code string. Values that do not fit into their field
+// are masked and alias other codes; EncodeChecked rejects them.
 func (e Simple511Code) Encode() string {
-	// Pack into 16 bits:
-	// - 5 bits for Class
-	// - 11 bits for ErrorType
-	// Resulting in two bytes: [CCCCCEEE][EEEEEEEE]
-	packed := uint32(e.Class)<<11 | uint32(e.ErrType)
-
-	// Convert type and data to base36
-	typeStr := toBase36(uint32(e.GetType()), 1) // 1 char for type
-	dataStr := toBase36(packed, 4)              // 4 chars for 16 bits of data
+	return simple511Layout.EncodeMasked(uint32(e.Class), uint32(e.ErrType))
+}
 
-	return fmt.Sprintf("E%s%s", typeStr, dataStr)
+// EncodeChecked is like Encode but returns an ErrOverflow error for values
+// that do not fit into their field
+func (e Simple511Code) EncodeChecked() (string, error) {
+	return simple511Layout.Encode(uint32(e.Class), uint32(e.ErrType))
 }
 
 func DecodeSimple511Code(code string) (Simple511Code, error) {
-	if len(code) != 6 || code[0] != 'E' { // Format: E<type><data>
-		return Simple511Code{}, fmt.Errorf("invalid code format: %s", code)
-	}
-
-	// Parse type
-	typeVal := CodeType(fromBase36(code[1:2]))
-	if typeVal != CodeType(3) {
-		return Simple511Code{}, fmt.Errorf("invalid code type: %d", typeVal)
+	values, err := simple511Layout.Decode(code)
+	if err != nil {
+		return Simple511Code{}, err
 	}
 
-	// Parse data
-	packed := fromBase36(code[2:])
+	return Simple511Code{
+		Class:   Class5Code(values[0]),
+		ErrType: Simple11ErrorCode(values[1]),
+	}, nil
+}
 
-	// Validate packed data doesn't exceed our bit limits
-	if packed >= 1<<16 {
-		return Simple511Code{}, fmt.Errorf("packed value exceeds 16 bits: %d", packed)
+// ParseSimple511Name returns the code named like "http.not_found" and its
+// catalog entry
+func ParseSimple511Name(name string) (Simple511Code, Permutation, error) {
+	p, err := newNameParser("simple511", simple511Layout, name)
+	if err != nil {
+		return Simple511Code{}, Permutation{}, err
+	}
+	class, err := findName(p, 0, currentTrees().simple511, func(c Simple5ClassInfo) string { return c.Name })
+	if err != nil {
+		return Simple511Code{}, Permutation{}, err
+	}
+	errType, err := findName(p, 1, class.ErrorTypes, func(e Simple11ErrorInfo) string { return e.Name })
+	if err != nil {
+		return Simple511Code{}, Permutation{}, err
 	}
 
-	return Simple511Code{
-		Class:   Class5Code((packed >> 11) & 0x1F), // 5 bits
-		ErrType: Simple11ErrorCode(packed & 0x7FF), // 11 bits
-	}, nil
+	code := Simple511Code{Class: class.Value, ErrType: errType.Value}
+	perm, _ := Lookup(code)
+	return code, perm, nil
 }
 
 func (e Simple511Code) String() string {
-	for _, class := range Simple511CodeTree {
-		if class.Value != e.Class {
-			continue
-		}
-		for _, errType := range class.ErrorTypes {
-			if errType.Value != e.ErrType {
-				continue
-			}
-			return fmt.Sprintf("%s.%s", class.Name, errType.Name)
-		}
+	if entry, ok := simple511Index.get().lookup(simple511Layout, uint32(e.Class), uint32(e.ErrType)); ok {
+		return entry.name
 	}
 	return "invalid"
 }
 
+// Error implements the error interface so the code can be used as an
+// errors.Is target. As fmt prefers Error over String, %v and %s print the
+// encoded code and the path, e.g. "E301L0 http.not_found"; use String for
+// the path alone.
+func (e Simple511Code) Error() string {
+	return codeMessage(e)
+}
+
+// MarshalText and the other encoding methods store the code as its encoded
+// string
+func (e Simple511Code) MarshalText() ([]byte, error) {
+	encoded, err := e.EncodeChecked()
+	return []byte(encoded), err
+}
+
+func (e *Simple511Code) UnmarshalText(text []byte) error {
+	return decodeInto(e, string(text), DecodeSimple511Code)
+}
+
+func (e Simple511Code) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(e)
+}
+
+func (e *Simple511Code) UnmarshalJSON(data []byte) error {
+	return unmarshalCodeJSON(e, data, DecodeSimple511Code)
+}
+
+func (e Simple511Code) Value() (driver.Value, error) {
+	return codeValue(e)
+}
+
+func (e *Simple511Code) Scan(src any) error {
+	return scanCode(e, src, DecodeSimple511Code)
+}
+
+// LogValue logs the code as a group of its code, format, path and fields
+func (e Simple511Code) LogValue() slog.Value {
+	return slog.GroupValue(codeAttrs(e)...)
+}
+
 func (Simple511Code) GetPrefix() string {
 	return "E"
 }
 
 func (Simple511Code) GetFieldInfo() []FieldInfo {
-	return []FieldInfo{
-		{
-			Name:        "Class",
-			Bits:        5,
-			Description: "Identifies the error class (0-31)",
-			Values:      "http(1), max(31)",
-		},
-		{
-			Name:        "ErrorType",
-			Bits:        11,
-			Description: "Identifies the specific error (0-2047)",
-			Values:      "unknown(0), bad_request(1), unauthorized(2), etc",
-		},
+	var errTypes []Simple11ErrorInfo
+	tree := currentTrees().simple511
+	for _, class := range tree {
+		errTypes = append(errTypes, class.ErrorTypes...)
 	}
+
+	return simple511Layout.FieldInfo(
+		valueList(tree, func(c Simple5ClassInfo) (string, uint32) { return c.Name, uint32(c.Value) }),
+		valueList(errTypes, func(e Simple11ErrorInfo) (string, uint32) { return e.Name, uint32(e.Value) }),
+	)
 }
 
 func (Simple511Code) GetDocSection() DocSection {
 	return DocSection{
-		Title: "Simple 5-11 Format",
-		Description: `Each error code is composed of 16 bits encoded as follows:
-- Class (5 bits): Identifies the error class (allows up to 32 distinct classes)
-- ErrorType (11 bits): Identifies the specific error (allows up to 2048 errors per class)
-
-The format provides:
-- Up to 32 different classes
-- Up to 2048 different error types per class
-- Total of 65,536 possible unique error codes
-
-The code is encoded as E<type><data> where:
-- E: Fixed prefix
-- type: 1 character in base-36 encoding the error type
-- data: 4 characters in base-36 encoding the class and error type bits
-
-Bit layout before encoding:
-` + "```" + `
-[CCCCCEEE][EEEEEEEE]
-C: Class bits (5)
-E: ErrorType bits (11)
-` + "```",
-		Headers: []string{"Code", "Class.Type", "Description"},
+		Title:       "Simple 5-11 Format",
+		Description: simple511Layout.Describe(),
+		Headers:     []string{"Code", "Class.Type", "Description"},
 	}
 }
 
 func (Simple511Code) GetPermutations() []Permutation {
-	var perms []Permutation
+	return simple511Index.get().permutations()
+}
+
+var simple511Index = lazyIndex[Simple5ClassInfo]{tree: func(s *treeSet) []Simple5ClassInfo { return s.simple511 }, build: buildSimple511Index}
 
-	for _, class := range Simple511CodeTree {
+func buildSimple511Index(tree []Simple5ClassInfo) *catalogIndex {
+	idx := &catalogIndex{}
+	for _, class := range tree {
 		for _, errType := range class.ErrorTypes {
-			sc := Simple511Code{
-				Class:   class.Value,
-				ErrType: errType.Value,
-			}
-			code := sc.Encode()
-
-			// Validate by decoding and comparing
-			decoded, err := DecodeSimple511Code(code)
-			if err != nil {
-				panic(fmt.Sprintf("failed to decode simple 5-11 code: %v", err))
-			}
-			if decoded.Class != sc.Class || decoded.ErrType != sc.ErrType {
-				panic(fmt.Sprintf("decode mismatch: got %v, want %v", decoded, sc))
-			}
-
-			perms = append(perms, Permutation{
-				Type: CodeType(3),
-				Code: code,
+			name := class.Name + "." + errType.Name
+			idx.add(simple511Layout, name, Permutation{
 				Fields: map[string]string{
 					"Class":       class.Name,
 					"ErrorType":   errType.Name,
 					"Description": errType.Description,
 				},
 				TableFields: []string{
-					code,
-					fmt.Sprintf("%s.%s", class.Name, errType.Name),
+					name,
 					errType.Description,
 				},
-			})
+				HTTPStatus:   errType.HTTPStatus,
+				Localization: errType.Localization,
+				Lifecycle:    inheritLifecycle(errType.Lifecycle, class.Lifecycle),
+				Metadata:     inheritMetadata(errType.Metadata, class.Metadata),
+			}, uint32(class.Value), uint32(errType.Value))
 		}
 	}
-	return perms
+	return idx
+}
+
+func lookupSimple511(code ErrorType) (Permutation, bool) {
+	c, ok := code.(Simple511Code)
+	if !ok {
+		return Permutation{}, false
+	}
+	return simple511Index.get().permutation(simple511Layout, uint32(c.Class), uint32(c.ErrType))
 }
@@ -1,6 +1,10 @@
 package errors
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+)
 
 // TinyCode uses just a simple error type value from 0-1295 (00-ZZ in base-36)
 type TinyCode struct {
@@ -11,102 +15,135 @@ type TinyErrorInfo struct {
 	Value       uint16
 	Name        string
 	Description string
+	HTTPStatus  int // 0 derives the status from the name
+	Localization
+	Lifecycle
+	Metadata
 }
 
-// Predefined error codes
-var TinyCodeValues = []TinyErrorInfo{
-	{
-		Value:       0,
-		Name:        "unknown",
-		Description: "Unknown error",
-	},
-	{
-		Value:       1,
-		Name:        "validation",
-		Description: "Validation error",
-	},
-	{
-		Value:       2,
-		Name:        "not_found",
-		Description: "Resource not found",
-	},
-	{
-		Value:       3,
-		Name:        "unauthorized",
-		Description: "Unauthorized access",
-	},
-	{
-		Value:       4,
-		Name:        "bad_request",
-		Description: "Bad request",
-	},
-	{
-		Value:       1295,
-		Name:        "max",
-		Description: "Maximum error value (ZZ)",
+var tinyLayout = Layout{
+	Type:  CodeTypeTiny,
+	Width: 2, // 2 chars for error type, 00-ZZ
+	Fields: []LayoutField{
+		{Name: "ErrorType", Bits: 11, Description: "Error type value"},
 	},
 }
 
+func init() {
+	MustRegister(CodeTypeTiny, Format{
+		Name:      "tiny",
+		Prototype: TinyCode{},
+		Layout:    &tinyLayout,
+		Decode: func(code string) (ErrorType, error) {
+			return DecodeTinyCode(code)
+		},
+		Validate: validateTiny,
+		Lookup:   lookupTiny,
+		ParseName: func(name string) (ErrorType, error) {
+			code, _, err := ParseTinyName(name)
+			return code, err
+		},
+	})
+}
+
 func (TinyCode) GetType() CodeType {
-	return CodeType(CodeTypeTiny)
+	return CodeTypeTiny
 }
 
+// Encode returns the code string. Values that do not fit into their field
+// are masked and alias other codes; EncodeChecked rejects them.
 func (e TinyCode) Encode() string {
-	if e.ErrType > 1295 { // 35*36 + 35 = maximum value for two base-36 digits
-		panic(fmt.Sprintf("error type value %d exceeds maximum of 1295", e.ErrType))
-	}
-
-	// Convert type and data to base36
-	typeStr := toBase36(uint32(e.GetType()), 1) // always "0"
-	dataStr := toBase36(uint32(e.ErrType), 2)   // 2 chars for error type
+	return tinyLayout.EncodeMasked(uint32(e.ErrType))
+}
 
-	return fmt.Sprintf("E%s%s", typeStr, dataStr)
+// EncodeChecked is like Encode but returns an ErrOverflow error for values
+// that do not fit into their field
+func (e TinyCode) EncodeChecked() (string, error) {
+	return tinyLayout.Encode(uint32(e.ErrType))
 }
 
 func DecodeTinyCode(code string) (TinyCode, error) {
-	if len(code) != 4 || code[0] != 'E' { // Format: E0XX
-		return TinyCode{}, fmt.Errorf("invalid code format: %s", code)
+	values, err := tinyLayout.Decode(code)
+	if err != nil {
+		return TinyCode{}, err
 	}
 
-	// Parse type
-	typeVal := CodeType(fromBase36(code[1:2]))
-	if typeVal != CodeType(0) {
-		return TinyCode{}, fmt.Errorf("invalid code type: %d", typeVal)
-	}
+	return TinyCode{
+		ErrType: uint16(values[0]),
+	}, nil
+}
 
-	// Parse data
-	errType := fromBase36(code[2:])
-	if errType > 1295 {
-		return TinyCode{}, fmt.Errorf("error type value %d exceeds maximum of 1295", errType)
+// ParseTinyName returns the code named like "not_found" and its catalog entry
+func ParseTinyName(name string) (TinyCode, Permutation, error) {
+	p, err := newNameParser("tiny", tinyLayout, name)
+	if err != nil {
+		return TinyCode{}, Permutation{}, err
+	}
+	errType, err := findName(p, 0, currentTrees().tiny, func(e TinyErrorInfo) string { return e.Name })
+	if err != nil {
+		return TinyCode{}, Permutation{}, err
 	}
 
-	return TinyCode{
-		ErrType: uint16(errType),
-	}, nil
+	code := TinyCode{ErrType: errType.Value}
+	perm, _ := Lookup(code)
+	return code, perm, nil
 }
 
 func (e TinyCode) String() string {
-	for _, info := range TinyCodeValues {
-		if info.Value == e.ErrType {
-			return info.Name
-		}
+	if entry, ok := tinyIndex.get().lookup(tinyLayout, uint32(e.ErrType)); ok {
+		return entry.name
 	}
 	return fmt.Sprintf("error_%d", e.ErrType)
 }
 
+// Error implements the error interface so the code can be used as an
+// errors.Is target. As fmt prefers Error over String, %v and %s print the
+// encoded code and the path, e.g. "E002 not_found"; use String for the
+// path alone.
+func (e TinyCode) Error() string {
+	return codeMessage(e)
+}
+
+// MarshalText and the other encoding methods store the code as its encoded
+// string
+func (e TinyCode) MarshalText() ([]byte, error) {
+	encoded, err := e.EncodeChecked()
+	return []byte(encoded), err
+}
+
+func (e *TinyCode) UnmarshalText(text []byte) error {
+	return decodeInto(e, string(text), DecodeTinyCode)
+}
+
+func (e TinyCode) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(e)
+}
+
+func (e *TinyCode) UnmarshalJSON(data []byte) error {
+	return unmarshalCodeJSON(e, data, DecodeTinyCode)
+}
+
+func (e TinyCode) Value() (driver.Value, error) {
+	return codeValue(e)
+}
+
+func (e *TinyCode) Scan(src any) error {
+	return scanCode(e, src, DecodeTinyCode)
+}
+
+// LogValue logs the code as a group of its code, format, path and fields
+func (e TinyCode) LogValue() slog.Value {
+	return slog.GroupValue(codeAttrs(e)...)
+}
+
 func (TinyCode) GetPrefix() string {
 	return "E"
 }
 
 func (TinyCode) GetFieldInfo() []FieldInfo {
-	return []FieldInfo{
-		{
-			Name:        "ErrorType",
-			Bits:        11, // Actually only needs 11 bits since max value is 1295
-			Description: "Error type value (0-1295)",
-			Values:      "unknown(0), validation(1), not_found(2), etc",
-		},
-	}
+	return tinyLayout.FieldInfo(
+		valueList(currentTrees().tiny, func(e TinyErrorInfo) (string, uint32) { return e.Name, uint32(e.Value) }),
+	)
 }
 
 func (TinyCode) GetDocSection() DocSection {
@@ -114,14 +151,7 @@ func (TinyCode) GetDocSection() DocSection {
 		Title: "Tiny Format",
 		Description: `Simplest possible error code format using just an error type value.
 
-The format provides:
-- Values from 0 to 1295 (00 to ZZ in base-36)
-- Total of 1,296 possible unique error codes
-
-The code is encoded as E0XX where:
-- E: Fixed prefix
-- 0: Fixed type identifier
-- XX: Two base-36 characters encoding the error type (00-ZZ)
+` + tinyLayout.Describe() + `
 
 Examples:
 - E000: Unknown error
@@ -132,36 +162,36 @@ Examples:
 }
 
 func (TinyCode) GetPermutations() []Permutation {
-	var perms []Permutation
-
-	for _, errType := range TinyCodeValues {
-		tc := TinyCode{
-			ErrType: errType.Value,
-		}
-		code := tc.Encode()
-
-		// Validate by decoding and comparing
-		decoded, err := DecodeTinyCode(code)
-		if err != nil {
-			panic(fmt.Sprintf("failed to decode tiny code: %v", err))
-		}
-		if decoded.ErrType != tc.ErrType {
-			panic(fmt.Sprintf("decode mismatch: got %v, want %v", decoded, tc))
-		}
-
-		perms = append(perms, Permutation{
-			Type: CodeType(0),
-			Code: code,
+	return tinyIndex.get().permutations()
+}
+
+var tinyIndex = lazyIndex[TinyErrorInfo]{tree: func(s *treeSet) []TinyErrorInfo { return s.tiny }, build: buildTinyIndex}
+
+func buildTinyIndex(values []TinyErrorInfo) *catalogIndex {
+	idx := &catalogIndex{}
+	for _, errType := range values {
+		idx.add(tinyLayout, errType.Name, Permutation{
 			Fields: map[string]string{
 				"ErrorType":   errType.Name,
 				"Description": errType.Description,
 			},
 			TableFields: []string{
-				code,
 				errType.Name,
 				errType.Description,
 			},
-		})
+			HTTPStatus:   errType.HTTPStatus,
+			Localization: errType.Localization,
+			Lifecycle:    errType.Lifecycle,
+			Metadata:     errType.Metadata,
+		}, uint32(errType.Value))
+	}
+	return idx
+}
+
+func lookupTiny(code ErrorType) (Permutation, bool) {
+	c, ok := code.(TinyCode)
+	if !ok {
+		return Permutation{}, false
 	}
-	return perms
+	return tinyIndex.get().permutation(tinyLayout, uint32(c.ErrType))
 }
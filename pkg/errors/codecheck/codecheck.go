@@ -0,0 +1,230 @@
+// Package codecheck defines an analyzer that checks the use of error codes
+// against the catalog.
+//
+// It reports:
+//
+//   - composite literals of code types whose values are not in the catalog
+//     or do not fit the layout
+//   - string literals that look like codes but do not decode or are not in
+//     the catalog
+//   - calls to the Decode functions of the errors package whose error is
+//     ignored
+//   - uses of deprecated or retired codes, by generated name, literal or
+//     string
+//
+// Generated files are not checked.
+package codecheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/thommeo/error-code-design/internal/coderef"
+	"github.com/thommeo/error-code-design/pkg/catalog"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+const doc = `check error code literals, strings and decoding against the catalog
+
+The codecheck analyzer reports code literals and strings missing from the
+catalog, strings that look like codes but do not decode, ignored errors of
+the Decode functions and uses of deprecated codes.`
+
+// Analyzer checks the use of error codes
+var Analyzer = &analysis.Analyzer{
+	Name:     "codecheck",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var catalogPath string
+
+func init() {
+	Analyzer.Flags.StringVar(&catalogPath, "catalog", "", "YAML or JSON catalog file replacing the built-in code trees")
+}
+
+var (
+	setupOnce sync.Once
+	setupErr  error
+	names     map[string]string // Generated identifiers to encoded codes
+)
+
+// setup installs the catalog file, if any, once for all packages
+func setup() error {
+	setupOnce.Do(func() {
+		if catalogPath != "" {
+			c, err := catalog.Load(catalogPath)
+			if err != nil {
+				setupErr = err
+				return
+			}
+			c.Install()
+		}
+		names = coderef.Names()
+	})
+	return setupErr
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if err := setup(); err != nil {
+		return nil, err
+	}
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	generated := map[string]bool{}
+	for _, f := range pass.Files {
+		if ast.IsGenerated(f) {
+			generated[pass.Fset.File(f.Pos()).Name()] = true
+		}
+	}
+
+	nodes := []ast.Node{
+		(*ast.CompositeLit)(nil),
+		(*ast.BasicLit)(nil),
+		(*ast.Ident)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.GoStmt)(nil),
+		(*ast.DeferStmt)(nil),
+	}
+	insp.Preorder(nodes, func(n ast.Node) {
+		if generated[pass.Fset.File(n.Pos()).Name()] {
+			return
+		}
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			checkComposite(pass, n)
+		case *ast.BasicLit:
+			checkString(pass, n)
+		case *ast.Ident:
+			if code, ok := coderef.Ident(pass.TypesInfo, names, n); ok {
+				checkDeprecated(pass, n, code)
+			}
+		case *ast.ExprStmt:
+			if call, ok := ast.Unparen(n.X).(*ast.CallExpr); ok {
+				checkDecodeIgnored(pass, call)
+			}
+		case *ast.GoStmt:
+			checkDecodeIgnored(pass, n.Call)
+		case *ast.DeferStmt:
+			checkDecodeIgnored(pass, n.Call)
+		case *ast.AssignStmt:
+			checkDecodeAssign(pass, n)
+		}
+	})
+	return nil, nil
+}
+
+func checkComposite(pass *analysis.Pass, lit *ast.CompositeLit) {
+	encoded, err, ok := coderef.Composite(pass.TypesInfo, lit)
+	if !ok {
+		return
+	}
+	if err != nil {
+		pass.Reportf(lit.Pos(), "invalid code literal: %v", err)
+		return
+	}
+	if !known(encoded) {
+		pass.Reportf(lit.Pos(), "code %s is not in the catalog", encoded)
+		return
+	}
+	checkDeprecated(pass, lit, encoded)
+}
+
+func checkString(pass *analysis.Pass, lit *ast.BasicLit) {
+	s, ok := coderef.StringLit(lit)
+	if !ok {
+		return
+	}
+	if _, err := errors.Decode(s); err != nil {
+		pass.Reportf(lit.Pos(), "%q looks like a code but does not decode: %v", s, err)
+		return
+	}
+	if !known(s) {
+		pass.Reportf(lit.Pos(), "code %s is not in the catalog", s)
+		return
+	}
+	checkDeprecated(pass, lit, s)
+}
+
+// known reports whether an encoded code is in the catalog
+func known(encoded string) bool {
+	code, err := errors.Decode(encoded)
+	if err != nil {
+		return false
+	}
+	_, ok := errors.Lookup(code)
+	return ok
+}
+
+func checkDeprecated(pass *analysis.Pass, n ast.Node, encoded string) {
+	code, err := errors.Decode(encoded)
+	if err != nil {
+		return
+	}
+	p, ok := errors.Lookup(code)
+	if ok && p.Lifecycle.Deprecated() {
+		pass.Reportf(n.Pos(), "code %s (%s) is %s", encoded, code.String(), p.Lifecycle)
+	}
+}
+
+// decodeFunc returns the name of the function called if it is a Decode
+// function or method of the errors package returning an error last
+func decodeFunc(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return "", false
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != coderef.ErrorsPkgPath || !strings.HasPrefix(fn.Name(), "Decode") {
+		return "", false
+	}
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() == 0 || !types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type()) {
+		return "", false
+	}
+	name := fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		if named, ok := types.Unalias(recv.Type()).(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+	return "errors." + name, true
+}
+
+func checkDecodeIgnored(pass *analysis.Pass, call *ast.CallExpr) {
+	if name, ok := decodeFunc(pass, call); ok {
+		pass.Reportf(call.Pos(), "error returned by %s is not checked", name)
+	}
+}
+
+// checkDecodeAssign reports assignments of a Decode call that discard the
+// error with the blank identifier
+func checkDecodeAssign(pass *analysis.Pass, assign *ast.AssignStmt) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) < 2 {
+		return
+	}
+	call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	name, ok := decodeFunc(pass, call)
+	if !ok {
+		return
+	}
+	if id, ok := assign.Lhs[len(assign.Lhs)-1].(*ast.Ident); ok && id.Name == "_" {
+		pass.Reportf(call.Pos(), "error returned by %s is not checked", name)
+	}
+}
@@ -0,0 +1,31 @@
+package codecheck
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func TestAnalyzer(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = slices.Clone(saved)
+		for i, e := range errors.TinyCodeValues {
+			if e.Name == "bad_request" {
+				errors.TinyCodeValues[i].Lifecycle = errors.Lifecycle{Status: errors.StatusDeprecated, DeprecatedSince: "v1.4.0", Replacement: "E001"}
+			}
+		}
+	})
+
+	dir, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, dir, Analyzer, "./a")
+}
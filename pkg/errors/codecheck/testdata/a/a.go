@@ -0,0 +1,50 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func literals() {
+	_ = errors.AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 1}
+	_ = errors.AppComponentErrorCode{App: 1, Component: 9, SubComponent: 1, ErrType: 1} // want `code EA0PN29 is not in the catalog`
+	_ = errors.SimpleCode{2, 1}
+	_ = errors.SimpleCode{Class: 9, ErrType: 9}       // want `code E101S9 is not in the catalog`
+	_ = errors.Simple511Code{Class: 32}               // want `invalid code literal: .*`
+	_ = errors.TinyCode{ErrType: 4}                   // want `code E004 \(bad_request\) is deprecated since v1.4.0, use E001`
+	_ = errors.AppComponentErrorCode{App: appValue()} // not constant
+	_ = errors.AppComponentErrorCode{}                // no code
+}
+
+func appValue() errors.AppCode { return 1 }
+
+func strings() {
+	_ = "EA0MTXD"
+	_ = "E101S9" // want `code E101S9 is not in the catalog`
+	_ = "E1007"  // want `"E1007" looks like a code but does not decode: .*`
+	_ = "E004"   // want `code E004 \(bad_request\) is deprecated`
+	_ = "EACCES" // a word, not a code
+	_ = "ERROR"
+	fmt.Println("failed with", "E10075")
+}
+
+func names() {
+	_ = errors.TinyNotFound
+	_ = errors.TinyBadRequest               // want `code E004 \(bad_request\) is deprecated`
+	_ = errors.NewTinyBadRequest("invalid") // want `code E004 \(bad_request\) is deprecated`
+}
+
+func decoding(code string) error {
+	errors.Decode(code)                   // want `error returned by errors.Decode is not checked`
+	c, _ := errors.DecodeSimpleCode(code) // want `error returned by errors.DecodeSimpleCode is not checked`
+	_ = c
+	defer errors.DecodeTinyCode(code) // want `error returned by errors.DecodeTinyCode is not checked`
+	f, _ := errors.LookupFormatName("simple511")
+	_, _ = f.Layout.Decode(code) // want `error returned by errors.Layout.Decode is not checked`
+	if _, err := errors.DecodeAppComponentErrorCode(code); err != nil {
+		return err
+	}
+	_, err := errors.Decode(code)
+	return err
+}
@@ -0,0 +1,7 @@
+module example.com/app
+
+go 1.22.4
+
+require github.com/thommeo/error-code-design v0.0.0
+
+replace github.com/thommeo/error-code-design => ../../../..
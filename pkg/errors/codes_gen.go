@@ -0,0 +1,404 @@
+// Code generated by constgen. DO NOT EDIT.
+
+package errors
+
+// Tiny Format codes
+var (
+	// TinyUnknown is E000 (unknown): Unknown error
+	TinyUnknown = TinyCode{ErrType: 0}
+	// TinyValidation is E001 (validation): Validation error
+	TinyValidation = TinyCode{ErrType: 1}
+	// TinyNotFound is E002 (not_found): Resource not found
+	TinyNotFound = TinyCode{ErrType: 2}
+	// TinyUnauthorized is E003 (unauthorized): Unauthorized access
+	TinyUnauthorized = TinyCode{ErrType: 3}
+	// TinyBadRequest is E004 (bad_request): Bad request
+	TinyBadRequest = TinyCode{ErrType: 4}
+	// TinyMax is E0ZZ (max): Maximum error value (ZZ)
+	TinyMax = TinyCode{ErrType: 1295}
+)
+
+// NewTinyUnknown returns an error with code TinyUnknown
+func NewTinyUnknown(message string) *Error {
+	return New(TinyUnknown, message)
+}
+
+// NewTinyValidation returns an error with code TinyValidation
+func NewTinyValidation(message string) *Error {
+	return New(TinyValidation, message)
+}
+
+// NewTinyNotFound returns an error with code TinyNotFound
+func NewTinyNotFound(message string) *Error {
+	return New(TinyNotFound, message)
+}
+
+// NewTinyUnauthorized returns an error with code TinyUnauthorized
+func NewTinyUnauthorized(message string) *Error {
+	return New(TinyUnauthorized, message)
+}
+
+// NewTinyBadRequest returns an error with code TinyBadRequest
+func NewTinyBadRequest(message string) *Error {
+	return New(TinyBadRequest, message)
+}
+
+// NewTinyMax returns an error with code TinyMax
+func NewTinyMax(message string) *Error {
+	return New(TinyMax, message)
+}
+
+// Simple Format codes
+var (
+	// SimpleUnknownUnknown is E10000 (unknown.unknown): Unknown API error
+	SimpleUnknownUnknown = SimpleCode{Class: 0, ErrType: 0}
+	// SimpleAPIUnknown is E10074 (api.unknown): Unknown API error
+	SimpleAPIUnknown = SimpleCode{Class: 1, ErrType: 0}
+	// SimpleAPIValidationError is E10075 (api.validation_error): API validation error
+	SimpleAPIValidationError = SimpleCode{Class: 1, ErrType: 1}
+	// SimpleAPIAuthorizationError is E10076 (api.authorization_error): API authorization error
+	SimpleAPIAuthorizationError = SimpleCode{Class: 1, ErrType: 2}
+	// SimpleJobsUnknown is E100E8 (jobs.unknown): Unknown job error
+	SimpleJobsUnknown = SimpleCode{Class: 2, ErrType: 0}
+	// SimpleJobsDatabaseQuery is E100E9 (jobs.database_query): Database query error in job
+	SimpleJobsDatabaseQuery = SimpleCode{Class: 2, ErrType: 1}
+	// SimpleJobsTimeout is E100EA (jobs.timeout): Job execution timeout
+	SimpleJobsTimeout = SimpleCode{Class: 2, ErrType: 2}
+	// SimpleMaxUnknown is E11EDC (max.unknown): Unknown max class error
+	SimpleMaxUnknown = SimpleCode{Class: 255, ErrType: 0}
+	// SimpleMaxMax is E11EKF (max.max): Max error type number
+	SimpleMaxMax = SimpleCode{Class: 255, ErrType: 255}
+)
+
+// NewSimpleUnknownUnknown returns an error with code SimpleUnknownUnknown
+func NewSimpleUnknownUnknown(message string) *Error {
+	return New(SimpleUnknownUnknown, message)
+}
+
+// NewSimpleAPIUnknown returns an error with code SimpleAPIUnknown
+func NewSimpleAPIUnknown(message string) *Error {
+	return New(SimpleAPIUnknown, message)
+}
+
+// NewSimpleAPIValidationError returns an error with code SimpleAPIValidationError
+func NewSimpleAPIValidationError(message string) *Error {
+	return New(SimpleAPIValidationError, message)
+}
+
+// NewSimpleAPIAuthorizationError returns an error with code SimpleAPIAuthorizationError
+func NewSimpleAPIAuthorizationError(message string) *Error {
+	return New(SimpleAPIAuthorizationError, message)
+}
+
+// NewSimpleJobsUnknown returns an error with code SimpleJobsUnknown
+func NewSimpleJobsUnknown(message string) *Error {
+	return New(SimpleJobsUnknown, message)
+}
+
+// NewSimpleJobsDatabaseQuery returns an error with code SimpleJobsDatabaseQuery
+func NewSimpleJobsDatabaseQuery(message string) *Error {
+	return New(SimpleJobsDatabaseQuery, message)
+}
+
+// NewSimpleJobsTimeout returns an error with code SimpleJobsTimeout
+func NewSimpleJobsTimeout(message string) *Error {
+	return New(SimpleJobsTimeout, message)
+}
+
+// NewSimpleMaxUnknown returns an error with code SimpleMaxUnknown
+func NewSimpleMaxUnknown(message string) *Error {
+	return New(SimpleMaxUnknown, message)
+}
+
+// NewSimpleMaxMax returns an error with code SimpleMaxMax
+func NewSimpleMaxMax(message string) *Error {
+	return New(SimpleMaxMax, message)
+}
+
+// Simple 5-11 Format codes
+var (
+	// Simple511UnknownUnknown is E30000 (unknown.unknown): Unknown error
+	Simple511UnknownUnknown = Simple511Code{Class: 0, ErrType: 0}
+	// Simple511HTTPUnknown is E301KW (http.unknown): Unknown HTTP error
+	Simple511HTTPUnknown = Simple511Code{Class: 1, ErrType: 0}
+	// Simple511HTTPBadRequest is E301KX (http.bad_request): Bad request error (400)
+	Simple511HTTPBadRequest = Simple511Code{Class: 1, ErrType: 1}
+	// Simple511HTTPUnauthorized is E301KY (http.unauthorized): Unauthorized error (401)
+	Simple511HTTPUnauthorized = Simple511Code{Class: 1, ErrType: 2}
+	// Simple511HTTPForbidden is E301KZ (http.forbidden): Forbidden error (403)
+	Simple511HTTPForbidden = Simple511Code{Class: 1, ErrType: 3}
+	// Simple511HTTPNotFound is E301L0 (http.not_found): Not found error (404)
+	Simple511HTTPNotFound = Simple511Code{Class: 1, ErrType: 4}
+	// Simple511MaxUnknown is E31CZK (max.unknown): Unknown max class error
+	Simple511MaxUnknown = Simple511Code{Class: 31, ErrType: 0}
+	// Simple511MaxMax is E31EKF (max.max): Maximum error type value
+	Simple511MaxMax = Simple511Code{Class: 31, ErrType: 2047}
+)
+
+// NewSimple511UnknownUnknown returns an error with code Simple511UnknownUnknown
+func NewSimple511UnknownUnknown(message string) *Error {
+	return New(Simple511UnknownUnknown, message)
+}
+
+// NewSimple511HTTPUnknown returns an error with code Simple511HTTPUnknown
+func NewSimple511HTTPUnknown(message string) *Error {
+	return New(Simple511HTTPUnknown, message)
+}
+
+// NewSimple511HTTPBadRequest returns an error with code Simple511HTTPBadRequest
+func NewSimple511HTTPBadRequest(message string) *Error {
+	return New(Simple511HTTPBadRequest, message)
+}
+
+// NewSimple511HTTPUnauthorized returns an error with code Simple511HTTPUnauthorized
+func NewSimple511HTTPUnauthorized(message string) *Error {
+	return New(Simple511HTTPUnauthorized, message)
+}
+
+// NewSimple511HTTPForbidden returns an error with code Simple511HTTPForbidden
+func NewSimple511HTTPForbidden(message string) *Error {
+	return New(Simple511HTTPForbidden, message)
+}
+
+// NewSimple511HTTPNotFound returns an error with code Simple511HTTPNotFound
+func NewSimple511HTTPNotFound(message string) *Error {
+	return New(Simple511HTTPNotFound, message)
+}
+
+// NewSimple511MaxUnknown returns an error with code Simple511MaxUnknown
+func NewSimple511MaxUnknown(message string) *Error {
+	return New(Simple511MaxUnknown, message)
+}
+
+// NewSimple511MaxMax returns an error with code Simple511MaxMax
+func NewSimple511MaxMax(message string) *Error {
+	return New(Simple511MaxMax, message)
+}
+
+// App Component Format codes
+var (
+	// BackendHandlerUnknownUnknown is EA0MTQ8 (backend.handler.unknown.unknown): Unknown handler error
+	BackendHandlerUnknownUnknown = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 0, ErrType: 0}
+	// BackendHandlerUsersValidationError is EA0MTXD (backend.handler.users.validation_error): Input validation failed for user operation
+	BackendHandlerUsersValidationError = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 1}
+	// BackendHandlerUsersAuthorizationError is EA0MTXE (backend.handler.users.authorization_error): User lacks required permissions for operation
+	BackendHandlerUsersAuthorizationError = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 2}
+	// BackendHandlerRecordsValidationError is EA0MU4H (backend.handler.records.validation_error): Input validation failed for record operation
+	BackendHandlerRecordsValidationError = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 2, ErrType: 1}
+	// BackendHandlerRecordsAuthorizationError is EA0MU4I (backend.handler.records.authorization_error): User lacks required permissions for record operation
+	BackendHandlerRecordsAuthorizationError = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 2, ErrType: 2}
+	// BackendHandlerAnalyticsValidationError is EA0MUBL (backend.handler.analytics.validation_error): Input validation failed for analytics operation
+	BackendHandlerAnalyticsValidationError = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 3, ErrType: 1}
+	// BackendHandlerAnalyticsAuthorizationError is EA0MUBM (backend.handler.analytics.authorization_error): User lacks required permissions for analytics operation
+	BackendHandlerAnalyticsAuthorizationError = AppComponentErrorCode{App: 1, Component: 1, SubComponent: 3, ErrType: 2}
+	// BackendJobUnknownUnknown is EA0N6DC (backend.job.unknown.unknown): Unknown job error
+	BackendJobUnknownUnknown = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 0, ErrType: 0}
+	// BackendJobSyncDatabaseError is EA0N6KH (backend.job.sync.database_error): Database operation failed during sync
+	BackendJobSyncDatabaseError = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 1, ErrType: 1}
+	// BackendJobSyncExternalAPIError is EA0N6KI (backend.job.sync.external_api_error): External API call failed during sync
+	BackendJobSyncExternalAPIError = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 1, ErrType: 2}
+	// BackendJobSyncTimeout is EA0N6KJ (backend.job.sync.timeout): Operation timed out during sync
+	BackendJobSyncTimeout = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 1, ErrType: 3}
+	// BackendJobAnalyticsDatabaseError is EA0N6RL (backend.job.analytics.database_error): Database operation failed during analytics processing
+	BackendJobAnalyticsDatabaseError = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 2, ErrType: 1}
+	// BackendJobAnalyticsExternalAPIError is EA0N6RM (backend.job.analytics.external_api_error): External API call failed during analytics processing
+	BackendJobAnalyticsExternalAPIError = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 2, ErrType: 2}
+	// BackendJobAnalyticsTimeout is EA0N6RN (backend.job.analytics.timeout): Operation timed out during analytics processing
+	BackendJobAnalyticsTimeout = AppComponentErrorCode{App: 1, Component: 2, SubComponent: 2, ErrType: 3}
+	// FrontendUIUnknownUnknown is EA19ATC (frontend.ui.unknown.unknown): Unknown UI error
+	FrontendUIUnknownUnknown = AppComponentErrorCode{App: 2, Component: 1, SubComponent: 0, ErrType: 0}
+	// FrontendUIFormsValidationError is EA19B0H (frontend.ui.forms.validation_error): Form validation failed
+	FrontendUIFormsValidationError = AppComponentErrorCode{App: 2, Component: 1, SubComponent: 1, ErrType: 1}
+	// FrontendUIFormsSubmissionError is EA19B0I (frontend.ui.forms.submission_error): Form submission failed
+	FrontendUIFormsSubmissionError = AppComponentErrorCode{App: 2, Component: 1, SubComponent: 1, ErrType: 2}
+	// FrontendUIRoutingNotFound is EA19B7L (frontend.ui.routing.not_found): Route not found
+	FrontendUIRoutingNotFound = AppComponentErrorCode{App: 2, Component: 1, SubComponent: 2, ErrType: 1}
+	// FrontendUIRoutingUnauthorized is EA19B7M (frontend.ui.routing.unauthorized): Route access unauthorized
+	FrontendUIRoutingUnauthorized = AppComponentErrorCode{App: 2, Component: 1, SubComponent: 2, ErrType: 2}
+	// FrontendStateUnknownUnknown is EA19NGG (frontend.state.unknown.unknown): Unknown state error
+	FrontendStateUnknownUnknown = AppComponentErrorCode{App: 2, Component: 2, SubComponent: 0, ErrType: 0}
+	// FrontendStateStoreUpdateFailed is EA19NNL (frontend.state.store.update_failed): State update operation failed
+	FrontendStateStoreUpdateFailed = AppComponentErrorCode{App: 2, Component: 2, SubComponent: 1, ErrType: 1}
+	// FrontendStateStoreInvalidAction is EA19NNM (frontend.state.store.invalid_action): Invalid state action dispatched
+	FrontendStateStoreInvalidAction = AppComponentErrorCode{App: 2, Component: 2, SubComponent: 1, ErrType: 2}
+	// FrontendStatePersistenceStorageError is EA19NUP (frontend.state.persistence.storage_error): Local storage operation failed
+	FrontendStatePersistenceStorageError = AppComponentErrorCode{App: 2, Component: 2, SubComponent: 2, ErrType: 1}
+	// FrontendStatePersistenceSyncError is EA19NUQ (frontend.state.persistence.sync_error): State synchronization failed
+	FrontendStatePersistenceSyncError = AppComponentErrorCode{App: 2, Component: 2, SubComponent: 2, ErrType: 2}
+	// FrontendAPIUnknownUnknown is EA1A03K (frontend.api.unknown.unknown): Unknown API error
+	FrontendAPIUnknownUnknown = AppComponentErrorCode{App: 2, Component: 3, SubComponent: 0, ErrType: 0}
+	// FrontendAPIRequestNetworkError is EA1A0AP (frontend.api.request.network_error): Network request failed
+	FrontendAPIRequestNetworkError = AppComponentErrorCode{App: 2, Component: 3, SubComponent: 1, ErrType: 1}
+	// FrontendAPIRequestTimeout is EA1A0AQ (frontend.api.request.timeout): Request timed out
+	FrontendAPIRequestTimeout = AppComponentErrorCode{App: 2, Component: 3, SubComponent: 1, ErrType: 2}
+	// FrontendAPIRequestInvalidResponse is EA1A0AR (frontend.api.request.invalid_response): Invalid response received
+	FrontendAPIRequestInvalidResponse = AppComponentErrorCode{App: 2, Component: 3, SubComponent: 1, ErrType: 3}
+	// FrontendAPICacheCacheMiss is EA1A0HT (frontend.api.cache.cache_miss): Cache miss error
+	FrontendAPICacheCacheMiss = AppComponentErrorCode{App: 2, Component: 3, SubComponent: 2, ErrType: 1}
+	// FrontendAPICacheCacheInvalid is EA1A0HU (frontend.api.cache.cache_invalid): Cache invalidation error
+	FrontendAPICacheCacheInvalid = AppComponentErrorCode{App: 2, Component: 3, SubComponent: 2, ErrType: 2}
+	// MaxMaxComponentUnknownUnknown is EA9Z8QO (max.max_component.unknown.unknown): Unknown max component error
+	MaxMaxComponentUnknownUnknown = AppComponentErrorCode{App: 15, Component: 63, SubComponent: 0, ErrType: 0}
+	// MaxMaxComponentMaxSubcomponentMaxError is EA9ZLDR (max.max_component.max_subcomponent.max_error): Maximum possible error code value
+	MaxMaxComponentMaxSubcomponentMaxError = AppComponentErrorCode{App: 15, Component: 63, SubComponent: 63, ErrType: 255}
+)
+
+// NewBackendHandlerUnknownUnknown returns an error with code BackendHandlerUnknownUnknown
+func NewBackendHandlerUnknownUnknown(message string) *Error {
+	return New(BackendHandlerUnknownUnknown, message)
+}
+
+// NewBackendHandlerUsersValidationError returns an error with code BackendHandlerUsersValidationError
+func NewBackendHandlerUsersValidationError(message string) *Error {
+	return New(BackendHandlerUsersValidationError, message)
+}
+
+// NewBackendHandlerUsersAuthorizationError returns an error with code BackendHandlerUsersAuthorizationError
+func NewBackendHandlerUsersAuthorizationError(message string) *Error {
+	return New(BackendHandlerUsersAuthorizationError, message)
+}
+
+// NewBackendHandlerRecordsValidationError returns an error with code BackendHandlerRecordsValidationError
+func NewBackendHandlerRecordsValidationError(message string) *Error {
+	return New(BackendHandlerRecordsValidationError, message)
+}
+
+// NewBackendHandlerRecordsAuthorizationError returns an error with code BackendHandlerRecordsAuthorizationError
+func NewBackendHandlerRecordsAuthorizationError(message string) *Error {
+	return New(BackendHandlerRecordsAuthorizationError, message)
+}
+
+// NewBackendHandlerAnalyticsValidationError returns an error with code BackendHandlerAnalyticsValidationError
+func NewBackendHandlerAnalyticsValidationError(message string) *Error {
+	return New(BackendHandlerAnalyticsValidationError, message)
+}
+
+// NewBackendHandlerAnalyticsAuthorizationError returns an error with code BackendHandlerAnalyticsAuthorizationError
+func NewBackendHandlerAnalyticsAuthorizationError(message string) *Error {
+	return New(BackendHandlerAnalyticsAuthorizationError, message)
+}
+
+// NewBackendJobUnknownUnknown returns an error with code BackendJobUnknownUnknown
+func NewBackendJobUnknownUnknown(message string) *Error {
+	return New(BackendJobUnknownUnknown, message)
+}
+
+// NewBackendJobSyncDatabaseError returns an error with code BackendJobSyncDatabaseError
+func NewBackendJobSyncDatabaseError(message string) *Error {
+	return New(BackendJobSyncDatabaseError, message)
+}
+
+// NewBackendJobSyncExternalAPIError returns an error with code BackendJobSyncExternalAPIError
+func NewBackendJobSyncExternalAPIError(message string) *Error {
+	return New(BackendJobSyncExternalAPIError, message)
+}
+
+// NewBackendJobSyncTimeout returns an error with code BackendJobSyncTimeout
+func NewBackendJobSyncTimeout(message string) *Error {
+	return New(BackendJobSyncTimeout, message)
+}
+
+// NewBackendJobAnalyticsDatabaseError returns an error with code BackendJobAnalyticsDatabaseError
+func NewBackendJobAnalyticsDatabaseError(message string) *Error {
+	return New(BackendJobAnalyticsDatabaseError, message)
+}
+
+// NewBackendJobAnalyticsExternalAPIError returns an error with code BackendJobAnalyticsExternalAPIError
+func NewBackendJobAnalyticsExternalAPIError(message string) *Error {
+	return New(BackendJobAnalyticsExternalAPIError, message)
+}
+
+// NewBackendJobAnalyticsTimeout returns an error with code BackendJobAnalyticsTimeout
+func NewBackendJobAnalyticsTimeout(message string) *Error {
+	return New(BackendJobAnalyticsTimeout, message)
+}
+
+// NewFrontendUIUnknownUnknown returns an error with code FrontendUIUnknownUnknown
+func NewFrontendUIUnknownUnknown(message string) *Error {
+	return New(FrontendUIUnknownUnknown, message)
+}
+
+// NewFrontendUIFormsValidationError returns an error with code FrontendUIFormsValidationError
+func NewFrontendUIFormsValidationError(message string) *Error {
+	return New(FrontendUIFormsValidationError, message)
+}
+
+// NewFrontendUIFormsSubmissionError returns an error with code FrontendUIFormsSubmissionError
+func NewFrontendUIFormsSubmissionError(message string) *Error {
+	return New(FrontendUIFormsSubmissionError, message)
+}
+
+// NewFrontendUIRoutingNotFound returns an error with code FrontendUIRoutingNotFound
+func NewFrontendUIRoutingNotFound(message string) *Error {
+	return New(FrontendUIRoutingNotFound, message)
+}
+
+// NewFrontendUIRoutingUnauthorized returns an error with code FrontendUIRoutingUnauthorized
+func NewFrontendUIRoutingUnauthorized(message string) *Error {
+	return New(FrontendUIRoutingUnauthorized, message)
+}
+
+// NewFrontendStateUnknownUnknown returns an error with code FrontendStateUnknownUnknown
+func NewFrontendStateUnknownUnknown(message string) *Error {
+	return New(FrontendStateUnknownUnknown, message)
+}
+
+// NewFrontendStateStoreUpdateFailed returns an error with code FrontendStateStoreUpdateFailed
+func NewFrontendStateStoreUpdateFailed(message string) *Error {
+	return New(FrontendStateStoreUpdateFailed, message)
+}
+
+// NewFrontendStateStoreInvalidAction returns an error with code FrontendStateStoreInvalidAction
+func NewFrontendStateStoreInvalidAction(message string) *Error {
+	return New(FrontendStateStoreInvalidAction, message)
+}
+
+// NewFrontendStatePersistenceStorageError returns an error with code FrontendStatePersistenceStorageError
+func NewFrontendStatePersistenceStorageError(message string) *Error {
+	return New(FrontendStatePersistenceStorageError, message)
+}
+
+// NewFrontendStatePersistenceSyncError returns an error with code FrontendStatePersistenceSyncError
+func NewFrontendStatePersistenceSyncError(message string) *Error {
+	return New(FrontendStatePersistenceSyncError, message)
+}
+
+// NewFrontendAPIUnknownUnknown returns an error with code FrontendAPIUnknownUnknown
+func NewFrontendAPIUnknownUnknown(message string) *Error {
+	return New(FrontendAPIUnknownUnknown, message)
+}
+
+// NewFrontendAPIRequestNetworkError returns an error with code FrontendAPIRequestNetworkError
+func NewFrontendAPIRequestNetworkError(message string) *Error {
+	return New(FrontendAPIRequestNetworkError, message)
+}
+
+// NewFrontendAPIRequestTimeout returns an error with code FrontendAPIRequestTimeout
+func NewFrontendAPIRequestTimeout(message string) *Error {
+	return New(FrontendAPIRequestTimeout, message)
+}
+
+// NewFrontendAPIRequestInvalidResponse returns an error with code FrontendAPIRequestInvalidResponse
+func NewFrontendAPIRequestInvalidResponse(message string) *Error {
+	return New(FrontendAPIRequestInvalidResponse, message)
+}
+
+// NewFrontendAPICacheCacheMiss returns an error with code FrontendAPICacheCacheMiss
+func NewFrontendAPICacheCacheMiss(message string) *Error {
+	return New(FrontendAPICacheCacheMiss, message)
+}
+
+// NewFrontendAPICacheCacheInvalid returns an error with code FrontendAPICacheCacheInvalid
+func NewFrontendAPICacheCacheInvalid(message string) *Error {
+	return New(FrontendAPICacheCacheInvalid, message)
+}
+
+// NewMaxMaxComponentUnknownUnknown returns an error with code MaxMaxComponentUnknownUnknown
+func NewMaxMaxComponentUnknownUnknown(message string) *Error {
+	return New(MaxMaxComponentUnknownUnknown, message)
+}
+
+// NewMaxMaxComponentMaxSubcomponentMaxError returns an error with code MaxMaxComponentMaxSubcomponentMaxError
+func NewMaxMaxComponentMaxSubcomponentMaxError(message string) *Error {
+	return New(MaxMaxComponentMaxSubcomponentMaxError, message)
+}
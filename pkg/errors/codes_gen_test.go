@@ -0,0 +1,29 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestGeneratedCodes(t *testing.T) {
+	tests := []struct {
+		code ErrorType
+		path string
+	}{
+		{TinyNotFound, "not_found"},
+		{SimpleAPIValidationError, "api.validation_error"},
+		{Simple511HTTPNotFound, "http.not_found"},
+		{BackendHandlerUsersValidationError, "backend.handler.users.validation_error"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.code.String(); got != tt.path {
+			t.Errorf("%s.String() = %s; want %s", tt.code.Encode(), got, tt.path)
+		}
+	}
+
+	err := NewBackendJobSyncTimeout("sync of account 42")
+	if !stderrors.Is(err, BackendJobSyncTimeout) {
+		t.Errorf("NewBackendJobSyncTimeout() = %v; want code %v", err, BackendJobSyncTimeout)
+	}
+}
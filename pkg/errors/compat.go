@@ -0,0 +1,158 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// SnapshotEntry records a code and its meaning at one catalog version
+type SnapshotEntry struct {
+	Code        string `json:"code"`
+	Format      string `json:"format"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Retired     bool   `json:"retired,omitempty"` // Catalog entry with status retired
+}
+
+// Snapshot is the list of all codes of a catalog version, ordered by code
+type Snapshot []SnapshotEntry
+
+// TakeSnapshot returns the codes of all registered formats
+func TakeSnapshot() Snapshot {
+	var s Snapshot
+	for _, f := range Formats() {
+		for _, p := range f.Prototype.GetPermutations() {
+			s = append(s, SnapshotEntry{
+				Code:        p.Code,
+				Format:      f.Name,
+				Path:        permutationPath(f, p),
+				Description: p.Fields["Description"],
+				Retired:     p.Lifecycle.State() == StatusRetired,
+			})
+		}
+	}
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].Code < s[j].Code
+	})
+	return s
+}
+
+// permutationPath returns the dotted path of a permutation as reported by
+// the String method of its code
+func permutationPath(f Format, p Permutation) string {
+	code, err := f.Decode(p.Code)
+	if err != nil {
+		panic(fmt.Sprintf("failed to decode %s code: %v", f.Name, err))
+	}
+	return code.String()
+}
+
+// ReadSnapshot reads a snapshot written by Snapshot.Write
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	return s, nil
+}
+
+// Write writes the snapshot as indented JSON
+func (s Snapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ChangeKind classifies the difference of a code between two snapshots
+type ChangeKind string
+
+const (
+	ChangeAdded      ChangeKind = "added"      // New code with a new path
+	ChangeRemoved    ChangeKind = "removed"    // Code and path no longer exist
+	ChangeRenamed    ChangeKind = "renamed"    // Same code and description, new path
+	ChangeRenumbered ChangeKind = "renumbered" // Same path, new code
+	ChangeRepurposed ChangeKind = "repurposed" // Same code, new path and description
+	ChangeRetired    ChangeKind = "retired"    // Same code and path, retired in the catalog
+)
+
+// Breaking reports whether a change alters the meaning or the path of a
+// published code. Like Lock.Check, renames are breaking: a code keeps the
+// path it was allocated for.
+func (k ChangeKind) Breaking() bool {
+	switch k {
+	case ChangeRemoved, ChangeRenamed, ChangeRenumbered, ChangeRepurposed:
+		return true
+	}
+	return false
+}
+
+// Change is a difference between two snapshots. Old is nil for added codes
+// and New is nil for removed codes.
+type Change struct {
+	Kind ChangeKind
+	Old  *SnapshotEntry
+	New  *SnapshotEntry
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s %s (%s)", c.Kind, c.New.Code, c.New.Path)
+	case ChangeRemoved, ChangeRetired:
+		return fmt.Sprintf("%s %s (%s)", c.Kind, c.Old.Code, c.Old.Path)
+	case ChangeRenumbered:
+		return fmt.Sprintf("%s %s: %s -> %s", c.Kind, c.Old.Path, c.Old.Code, c.New.Code)
+	}
+	return fmt.Sprintf("%s %s: %s -> %s", c.Kind, c.Old.Code, c.Old.Path, c.New.Path)
+}
+
+// Compare classifies every difference between an old and a new snapshot.
+// A code that keeps its path is reported only if the new catalog retires
+// it; description edits are not reported.
+func Compare(old, new Snapshot) []Change {
+	newByCode := map[string]*SnapshotEntry{}
+	newByPath := map[string]*SnapshotEntry{}
+	for i := range new {
+		newByCode[new[i].Code] = &new[i]
+		newByPath[new[i].Format+":"+new[i].Path] = &new[i]
+	}
+	oldCodes := map[string]bool{}
+	oldPaths := map[string]bool{}
+	for _, e := range old {
+		oldCodes[e.Code] = true
+		oldPaths[e.Format+":"+e.Path] = true
+	}
+
+	var changes []Change
+	for i := range old {
+		o := &old[i]
+		if n, ok := newByCode[o.Code]; ok {
+			switch {
+			case n.Path == o.Path:
+				if n.Retired && !o.Retired {
+					changes = append(changes, Change{Kind: ChangeRetired, Old: o, New: n})
+				}
+			case n.Description == o.Description:
+				changes = append(changes, Change{Kind: ChangeRenamed, Old: o, New: n})
+			default:
+				changes = append(changes, Change{Kind: ChangeRepurposed, Old: o, New: n})
+			}
+			continue
+		}
+		if n, ok := newByPath[o.Format+":"+o.Path]; ok {
+			changes = append(changes, Change{Kind: ChangeRenumbered, Old: o, New: n})
+			continue
+		}
+		changes = append(changes, Change{Kind: ChangeRemoved, Old: o})
+	}
+
+	for i := range new {
+		n := &new[i]
+		if !oldCodes[n.Code] && !oldPaths[n.Format+":"+n.Path] {
+			changes = append(changes, Change{Kind: ChangeAdded, New: n})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	old := Snapshot{
+		{Code: "E10101", Format: "simple", Path: "api.validation_error", Description: "API validation error"},
+		{Code: "E10102", Format: "simple", Path: "api.authorization_error", Description: "API authorization error"},
+		{Code: "E10103", Format: "simple", Path: "api.conflict", Description: "Conflicting update"},
+		{Code: "E10104", Format: "simple", Path: "api.rate_limited", Description: "Too many requests"},
+		{Code: "E10105", Format: "simple", Path: "api.gone", Description: "Resource gone"},
+		{Code: "E10108", Format: "simple", Path: "api.legacy", Description: "Legacy endpoint"},
+		{Code: "E10109", Format: "simple", Path: "api.obsolete", Description: "Obsolete endpoint", Retired: true},
+	}
+	new := Snapshot{
+		{Code: "E10101", Format: "simple", Path: "api.validation_error", Description: "Request validation error"},
+		{Code: "E10102", Format: "simple", Path: "api.auth_error", Description: "API authorization error"},
+		{Code: "E10103", Format: "simple", Path: "api.payment_required", Description: "Payment required"},
+		{Code: "E10106", Format: "simple", Path: "api.rate_limited", Description: "Too many requests"},
+		{Code: "E10107", Format: "simple", Path: "api.teapot", Description: "I'm a teapot"},
+		{Code: "E10108", Format: "simple", Path: "api.legacy", Description: "Legacy endpoint", Retired: true},
+		{Code: "E10109", Format: "simple", Path: "api.obsolete", Description: "Obsolete endpoint", Retired: true},
+	}
+
+	want := []struct {
+		kind     ChangeKind
+		breaking bool
+		str      string
+	}{
+		{ChangeRenamed, true, "renamed E10102: api.authorization_error -> api.auth_error"},
+		{ChangeRepurposed, true, "repurposed E10103: api.conflict -> api.payment_required"},
+		{ChangeRenumbered, true, "renumbered api.rate_limited: E10104 -> E10106"},
+		{ChangeRemoved, true, "removed E10105 (api.gone)"},
+		{ChangeRetired, false, "retired E10108 (api.legacy)"},
+		{ChangeAdded, false, "added E10107 (api.teapot)"},
+	}
+
+	changes := Compare(old, new)
+	if len(changes) != len(want) {
+		t.Fatalf("Compare() returned %d changes; want %d: %v", len(changes), len(want), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Kind != w.kind || c.Kind.Breaking() != w.breaking || c.String() != w.str {
+			t.Errorf("change %d = %s (breaking %v); want %s (breaking %v)", i, c, c.Kind.Breaking(), w.str, w.breaking)
+		}
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	snap := TakeSnapshot()
+
+	var buf bytes.Buffer
+	if err := snap.Write(&buf); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	read, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() returned error: %v", err)
+	}
+
+	if changes := Compare(snap, read); len(changes) != 0 {
+		t.Errorf("Compare() of identical snapshots = %v; want no changes", changes)
+	}
+}
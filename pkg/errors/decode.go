@@ -0,0 +1,100 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// Sentinel errors wrapped by DecodeError, for use with errors.Is
+var (
+	ErrInvalidLength = stderrors.New("invalid code length")
+	ErrInvalidChar   = stderrors.New("invalid character")
+	ErrUnknownType   = stderrors.New("unknown code type")
+	ErrOverflow      = stderrors.New("value overflows field")
+)
+
+// DecodeError describes why a code string could not be decoded
+type DecodeError struct {
+	Code string
+	Pos  int   // Offending character position, -1 when not applicable
+	Err  error // One of the Err* sentinels
+}
+
+func (e *DecodeError) Error() string {
+	if e.Pos >= 0 {
+		return fmt.Sprintf("decode %q: %v at position %d", e.Code, e.Err, e.Pos)
+	}
+	return fmt.Sprintf("decode %q: %v", e.Code, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Decode reads the type character following the E prefix and decodes the
+// code with the matching registered format
+func Decode(code string) (ErrorType, error) {
+	if len(code) < 2 {
+		return nil, &DecodeError{Code: code, Pos: -1, Err: ErrInvalidLength}
+	}
+	if code[0] != 'E' {
+		return nil, &DecodeError{Code: code, Pos: 0, Err: ErrInvalidChar}
+	}
+	for i := 1; i < len(code); i++ {
+		if !isBase36Char(code[i]) {
+			return nil, &DecodeError{Code: code, Pos: i, Err: ErrInvalidChar}
+		}
+	}
+
+	t, err := fromBase36(code[1:2])
+	if err != nil {
+		return nil, err
+	}
+	f, ok := LookupFormat(CodeType(t))
+	if !ok {
+		return nil, &DecodeError{Code: code, Pos: 1, Err: ErrUnknownType}
+	}
+	if len(code) != f.Length() {
+		return nil, &DecodeError{Code: code, Pos: -1, Err: ErrInvalidLength}
+	}
+	return f.Decode(code)
+}
+
+// EncodeChecked encodes code strictly, returning an ErrOverflow error for
+// values that do not fit into the format. Encode masks such values instead,
+// and formats without EncodeChecked that panic are recovered.
+func EncodeChecked(code ErrorType) (encoded string, err error) {
+	if c, ok := code.(CheckedEncoder); ok {
+		return c.EncodeChecked()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrOverflow, r)
+		}
+	}()
+	return code.Encode(), nil
+}
+
+// parseCode validates the length, prefix, characters and type of a code of
+// the given format and returns its packed data
+func parseCode(code string, t CodeType, length int) (uint32, error) {
+	if len(code) != length {
+		return 0, &DecodeError{Code: code, Pos: -1, Err: ErrInvalidLength}
+	}
+	if code[0] != 'E' {
+		return 0, &DecodeError{Code: code, Pos: 0, Err: ErrInvalidChar}
+	}
+	for i := 1; i < len(code); i++ {
+		if !isBase36Char(code[i]) {
+			return 0, &DecodeError{Code: code, Pos: i, Err: ErrInvalidChar}
+		}
+	}
+	if typ, err := fromBase36(code[1:2]); err != nil || CodeType(typ) != t {
+		return 0, &DecodeError{Code: code, Pos: 1, Err: ErrUnknownType}
+	}
+	packed, err := fromBase36(code[2:])
+	if err != nil {
+		return 0, &DecodeError{Code: code, Pos: -1, Err: ErrOverflow}
+	}
+	return packed, nil
+}
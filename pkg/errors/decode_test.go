@@ -0,0 +1,155 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		code ErrorType
+	}{
+		{"tiny", TinyCode{ErrType: 2}},
+		{"simple", SimpleCode{Class: 1, ErrType: 2}},
+		{"simple511", Simple511Code{Class: 1, ErrType: 4}},
+		{"app component", AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := Decode(tt.code.Encode())
+			if err != nil {
+				t.Fatalf("Decode(%s) returned error: %v", tt.code.Encode(), err)
+			}
+			if decoded != tt.code {
+				t.Errorf("Decode(%s) = %v; want %v", tt.code.Encode(), decoded, tt.code)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		err  error
+		pos  int
+	}{
+		{"empty", "", ErrInvalidLength, -1},
+		{"prefix only", "E", ErrInvalidLength, -1},
+		{"wrong prefix", "X1000", ErrInvalidChar, 0},
+		{"lowercase", "Ea0001", ErrInvalidChar, 1},
+		{"space", "E1 000", ErrInvalidChar, 2},
+		{"unknown type", "EZ0000", ErrUnknownType, 1},
+		{"tiny too long", "E0001", ErrInvalidLength, -1},
+		{"app component too short", "EA0000", ErrInvalidLength, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.code)
+			if !stderrors.Is(err, tt.err) {
+				t.Fatalf("Decode(%q) error = %v; want %v", tt.code, err, tt.err)
+			}
+			var decErr *DecodeError
+			if !stderrors.As(err, &decErr) {
+				t.Fatalf("Decode(%q) error is not a *DecodeError", tt.code)
+			}
+			if decErr.Pos != tt.pos {
+				t.Errorf("Decode(%q) error position = %d; want %d", tt.code, decErr.Pos, tt.pos)
+			}
+		})
+	}
+}
+
+func TestFormatDecodersInvalid(t *testing.T) {
+	decoders := map[string]func(string) error{
+		"tiny":          func(s string) error { _, err := DecodeTinyCode(s); return err },
+		"simple":        func(s string) error { _, err := DecodeSimpleCode(s); return err },
+		"simple511":     func(s string) error { _, err := DecodeSimple511Code(s); return err },
+		"app_component": func(s string) error { _, err := DecodeAppComponentErrorCode(s); return err },
+	}
+
+	tests := []struct {
+		name    string
+		decoder string
+		code    string
+		err     error
+		pos     int
+	}{
+		{"tiny lowercase", "tiny", "E0zz", ErrInvalidChar, 2},
+		{"tiny wrong type", "tiny", "E100", ErrUnknownType, 1},
+		{"simple lowercase type", "simple", "Ea0001", ErrInvalidChar, 1},
+		{"simple space", "simple", "E1 000", ErrInvalidChar, 2},
+		{"simple empty", "simple", "", ErrInvalidLength, -1},
+		{"simple overflow", "simple", "E1ZZZZ", ErrOverflow, -1},
+		{"simple511 wrong prefix", "simple511", "X30000", ErrInvalidChar, 0},
+		{"simple511 overflow", "simple511", "E3ZZZZ", ErrOverflow, -1},
+		{"app component unicode", "app_component", "EA0⌘1", ErrInvalidChar, 3},
+		{"app component overflow", "app_component", "EAZZZZZ", ErrOverflow, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decoders[tt.decoder](tt.code)
+			if !stderrors.Is(err, tt.err) {
+				t.Fatalf("decode(%q) error = %v; want %v", tt.code, err, tt.err)
+			}
+			var decErr *DecodeError
+			if !stderrors.As(err, &decErr) {
+				t.Fatalf("decode(%q) error is not a *DecodeError", tt.code)
+			}
+			if decErr.Pos != tt.pos {
+				t.Errorf("decode(%q) error position = %d; want %d", tt.code, decErr.Pos, tt.pos)
+			}
+		})
+	}
+}
+
+func TestEncodeChecked(t *testing.T) {
+	tests := []struct {
+		name string
+		code ErrorType
+		ok   bool
+	}{
+		{"tiny max", TinyCode{ErrType: 1295}, true},
+		{"tiny overflow", TinyCode{ErrType: 1296}, false},
+		{"simple511 class overflow", Simple511Code{Class: 32}, false},
+		{"simple511 error type overflow", Simple511Code{ErrType: 2048}, false},
+		{"app overflow", AppComponentErrorCode{App: 16}, false},
+		{"app component max", AppComponentErrorCode{App: 15, Component: 63, SubComponent: 63, ErrType: 255}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := EncodeChecked(tt.code)
+			if tt.ok {
+				if err != nil || encoded != tt.code.Encode() {
+					t.Errorf("EncodeChecked() = %q, %v; want %q", encoded, err, tt.code.Encode())
+				}
+				return
+			}
+			if !stderrors.Is(err, ErrOverflow) {
+				t.Errorf("EncodeChecked() error = %v; want %v", err, ErrOverflow)
+			}
+		})
+	}
+}
+
+func TestEncodeMasks(t *testing.T) {
+	tests := []struct {
+		code  ErrorType
+		alias ErrorType
+	}{
+		{AppComponentErrorCode{App: 16, Component: 1}, AppComponentErrorCode{Component: 1}},
+		{Simple511Code{Class: 32, ErrType: 3}, Simple511Code{ErrType: 3}},
+		{TinyCode{ErrType: 1296}, TinyCode{}},
+	}
+
+	for _, tt := range tests {
+		if got, want := tt.code.Encode(), tt.alias.Encode(); got != want {
+			t.Errorf("%#v.Encode() = %q; want the masked code %q", tt.code, got, want)
+		}
+	}
+}
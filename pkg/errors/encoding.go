@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+)
+
+// The code types implement encoding.TextMarshaler, json.Marshaler,
+// sql.Scanner and driver.Valuer and their counterparts with the helpers
+// below, so that codes are stored as their encoded strings.
+
+// decodeInto decodes text with the decoder of the code type and stores the
+// result in dst
+func decodeInto[T ErrorType](dst *T, text string, decode func(string) (T, error)) error {
+	code, err := decode(text)
+	if err != nil {
+		return err
+	}
+	*dst = code
+	return nil
+}
+
+// unmarshalCodeJSON decodes a JSON string, or the code of an object written
+// by Detailed, into dst. null leaves dst unchanged.
+func unmarshalCodeJSON[T ErrorType](dst *T, data []byte, decode func(string) (T, error)) error {
+	var text string
+	switch {
+	case string(data) == "null":
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	case len(data) > 0 && data[0] == '{':
+		var obj detailedJSON
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		text = obj.Code
+	default:
+		return fmt.Errorf("unmarshal %T: expected a JSON string or object, got %s", *dst, data)
+	}
+	return decodeInto(dst, text, decode)
+}
+
+// marshalCodeJSON returns the encoded code as a JSON string
+func marshalCodeJSON(code ErrorType) ([]byte, error) {
+	encoded, err := EncodeChecked(code)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// scanCode decodes a database value into dst. Trailing spaces are removed
+// since fixed-width character columns are padded with them.
+func scanCode[T ErrorType](dst *T, src any, decode func(string) (T, error)) error {
+	switch src := src.(type) {
+	case string:
+		return decodeInto(dst, strings.TrimRight(src, " "), decode)
+	case []byte:
+		return decodeInto(dst, strings.TrimRight(string(src), " "), decode)
+	case nil:
+		return fmt.Errorf("scan %T: NULL is not a code, scan into sql.Null[%T] instead", *dst, *dst)
+	}
+	return fmt.Errorf("scan %T: unsupported source type %T", *dst, src)
+}
+
+// codeValue returns the encoded code as a database value
+func codeValue(code ErrorType) (driver.Value, error) {
+	return EncodeChecked(code)
+}
+
+// Detailed wraps a code so that it marshals to a JSON object with its
+// format, catalog path and field values instead of the encoded string.
+// Unmarshaling accepts both forms, for codes of any registered format.
+type Detailed struct {
+	Code ErrorType
+}
+
+// detailedJSON is the JSON object written by Detailed
+type detailedJSON struct {
+	Code   string            `json:"code"`
+	Format string            `json:"format,omitempty"`
+	Path   string            `json:"path,omitempty"` // Empty if the code is not in the catalog
+	Fields map[string]uint32 `json:"fields,omitempty"`
+}
+
+func (d Detailed) MarshalJSON() ([]byte, error) {
+	if d.Code == nil {
+		return []byte("null"), nil
+	}
+	encoded, err := EncodeChecked(d.Code)
+	if err != nil {
+		return nil, err
+	}
+	obj := detailedJSON{Code: encoded}
+	if _, ok := Lookup(d.Code); ok {
+		obj.Path = d.Code.String()
+	}
+	if f, ok := LookupFormat(d.Code.GetType()); ok {
+		obj.Format = f.Name
+		if f.Layout != nil {
+			values, err := f.Layout.Decode(encoded)
+			if err != nil {
+				return nil, err
+			}
+			obj.Fields = make(map[string]uint32, len(values))
+			for i, field := range f.Layout.Fields {
+				obj.Fields[naming.FieldKey(field.Name)] = values[i]
+			}
+		}
+	}
+	return json.Marshal(obj)
+}
+
+func (d *Detailed) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		d.Code = nil
+		return nil
+	}
+	return unmarshalCodeJSON(&d.Code, data, Decode)
+}
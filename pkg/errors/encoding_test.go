@@ -0,0 +1,164 @@
+package errors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+)
+
+// codec is implemented by pointers to all code types
+type codec interface {
+	ErrorType
+	encoding.TextUnmarshaler
+	json.Unmarshaler
+	sql.Scanner
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		code ErrorType
+		dst  func() codec
+	}{
+		{"tiny", TinyCode{ErrType: 2}, func() codec { return new(TinyCode) }},
+		{"simple", SimpleCode{Class: 1, ErrType: 2}, func() codec { return new(SimpleCode) }},
+		{"simple511", Simple511Code{Class: 1, ErrType: 4}, func() codec { return new(Simple511Code) }},
+		{"app component", AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 1}, func() codec { return new(AppComponentErrorCode) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := tt.code.Encode()
+			deref := func(c codec) ErrorType {
+				switch c := c.(type) {
+				case *TinyCode:
+					return *c
+				case *SimpleCode:
+					return *c
+				case *Simple511Code:
+					return *c
+				case *AppComponentErrorCode:
+					return *c
+				}
+				return nil
+			}
+
+			text, err := tt.code.(encoding.TextMarshaler).MarshalText()
+			if err != nil || string(text) != encoded {
+				t.Errorf("MarshalText() = %q, %v; want %q", text, err, encoded)
+			}
+			dst := tt.dst()
+			if err := dst.UnmarshalText(text); err != nil || deref(dst) != tt.code {
+				t.Errorf("UnmarshalText(%q) = %v, %v; want %v", text, deref(dst), err, tt.code)
+			}
+
+			data, err := json.Marshal(tt.code)
+			if err != nil || string(data) != `"`+encoded+`"` {
+				t.Errorf("json.Marshal() = %s, %v; want %q", data, err, encoded)
+			}
+			dst = tt.dst()
+			if err := json.Unmarshal(data, dst); err != nil || deref(dst) != tt.code {
+				t.Errorf("json.Unmarshal(%s) = %v, %v; want %v", data, deref(dst), err, tt.code)
+			}
+
+			detailed, err := json.Marshal(Detailed{Code: tt.code})
+			if err != nil {
+				t.Fatalf("Marshal(Detailed) returned error: %v", err)
+			}
+			dst = tt.dst()
+			if err := json.Unmarshal(detailed, dst); err != nil || deref(dst) != tt.code {
+				t.Errorf("json.Unmarshal(%s) = %v, %v; want %v", detailed, deref(dst), err, tt.code)
+			}
+
+			value, err := tt.code.(driver.Valuer).Value()
+			if err != nil || value != encoded {
+				t.Errorf("Value() = %v, %v; want %q", value, err, encoded)
+			}
+			for _, src := range []any{encoded, []byte(encoded), encoded + "  "} {
+				dst = tt.dst()
+				if err := dst.Scan(src); err != nil || deref(dst) != tt.code {
+					t.Errorf("Scan(%q) = %v, %v; want %v", src, deref(dst), err, tt.code)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodingInvalidInput(t *testing.T) {
+	var code SimpleCode
+
+	if err := code.UnmarshalText([]byte("E0001")); !stderrors.Is(err, ErrInvalidLength) {
+		t.Errorf("UnmarshalText(tiny code) = %v; want ErrInvalidLength", err)
+	}
+	if err := code.UnmarshalText([]byte("E1a001")); !stderrors.Is(err, ErrInvalidChar) {
+		t.Errorf("UnmarshalText(lowercase) = %v; want ErrInvalidChar", err)
+	}
+	if err := json.Unmarshal([]byte(`"E30000"`), &code); !stderrors.Is(err, ErrUnknownType) {
+		t.Errorf("json.Unmarshal(simple511 code) = %v; want ErrUnknownType", err)
+	}
+	if err := json.Unmarshal([]byte(`42`), &code); err == nil {
+		t.Error("json.Unmarshal(number) returned nil error")
+	}
+	if err := json.Unmarshal([]byte(`{"format":"simple"}`), &code); !stderrors.Is(err, ErrInvalidLength) {
+		t.Errorf("json.Unmarshal(object without code) = %v; want ErrInvalidLength", err)
+	}
+	if err := code.Scan(nil); err == nil {
+		t.Error("Scan(nil) returned nil error")
+	}
+	if err := code.Scan(42); err == nil {
+		t.Error("Scan(42) returned nil error")
+	}
+	if code != (SimpleCode{}) {
+		t.Errorf("code = %v after failed decodes; want zero value", code)
+	}
+
+	if _, err := json.Marshal(Simple511Code{Class: 32}); !stderrors.Is(err, ErrOverflow) {
+		t.Errorf("json.Marshal(overflowing code) = %v; want ErrOverflow", err)
+	}
+}
+
+func TestNullableScan(t *testing.T) {
+	var code sql.Null[SimpleCode]
+	if err := code.Scan(nil); err != nil || code.Valid {
+		t.Errorf("Scan(nil) = %+v, %v; want invalid", code, err)
+	}
+	want := SimpleCode{Class: 1, ErrType: 1}
+	if err := code.Scan(want.Encode()); err != nil || !code.Valid || code.V != want {
+		t.Errorf("Scan(%s) = %+v, %v; want %v", want.Encode(), code, err, want)
+	}
+}
+
+func TestDetailedJSON(t *testing.T) {
+	code := AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 1}
+	data, err := json.Marshal(Detailed{Code: code})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	want := `{"code":"` + code.Encode() + `","format":"app_component","path":"` + code.String() +
+		`","fields":{"app":1,"component":1,"error_type":1,"sub_component":1}}`
+	if string(data) != want {
+		t.Errorf("Marshal(Detailed) = %s; want %s", data, want)
+	}
+
+	var d Detailed
+	if err := json.Unmarshal(data, &d); err != nil || d.Code != code {
+		t.Errorf("Unmarshal(%s) = %v, %v; want %v", data, d.Code, err, code)
+	}
+	simple := SimpleCode{Class: 1, ErrType: 1}
+	if err := json.Unmarshal([]byte(`"`+simple.Encode()+`"`), &d); err != nil || d.Code != simple {
+		t.Errorf("Unmarshal(%s) = %v, %v; want %v", simple.Encode(), d.Code, err, simple)
+	}
+
+	unknown := AppComponentErrorCode{App: 15}
+	data, err = json.Marshal(Detailed{Code: unknown})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	var obj detailedJSON
+	if err := json.Unmarshal(data, &obj); err != nil || obj.Path != "" || obj.Fields["app"] != 15 {
+		t.Errorf("Marshal(Detailed{unknown}) = %s; want no path", data)
+	}
+}
@@ -0,0 +1,153 @@
+// Package errgrpc converts errors carrying catalog codes to gRPC statuses
+// and back. It is a separate module so that neither the core nor the other
+// packages of the repository depend on gRPC.
+//
+// A status created by ToStatus carries an ErrorInfo detail with the encoded
+// code as reason, the app name (or the format name for formats without
+// apps) as domain and, for catalog codes, the fields of the code's path as
+// metadata:
+//
+//	reason:   "EA0MTXD"
+//	domain:   "backend"
+//	metadata: {app: backend, component: handler, sub_component: users,
+//	           error_type: validation_error, path: backend.handler.users.validation_error}
+package errgrpc
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+	"github.com/thommeo/error-code-design/pkg/errors/errhttp"
+)
+
+// httpCodes maps the HTTP statuses of codes to canonical codes. Statuses
+// missing from the map become Internal for 5xx and Unknown otherwise.
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Unknown,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// Code returns the canonical gRPC code of a catalog code, mapped from its
+// HTTP status as returned by errhttp.Status. Codes without a specific
+// status, including codes missing from the catalog, get Unknown.
+func Code(code errors.ErrorType) codes.Code {
+	httpStatus := errhttp.Status(code)
+	if c, ok := httpCodes[httpStatus]; ok {
+		return c
+	}
+	if httpStatus >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// domain returns the ErrorInfo domain of a code: the app name of its
+// catalog entry, else the format name
+func domain(code errors.ErrorType) string {
+	if p, ok := errors.Lookup(code); ok {
+		if app, ok := p.Fields["App"]; ok {
+			return app
+		}
+	}
+	f, _ := errors.LookupFormat(code.GetType())
+	return f.Name
+}
+
+// ErrorInfo returns the ErrorInfo detail describing a code. Codes missing
+// from the catalog get the encoded code as reason and no metadata; nil is
+// returned only for codes that cannot be encoded.
+func ErrorInfo(code errors.ErrorType) *errdetails.ErrorInfo {
+	encoded, err := errors.EncodeChecked(code)
+	if err != nil {
+		return nil
+	}
+	info := &errdetails.ErrorInfo{
+		Reason: encoded,
+		Domain: domain(code),
+	}
+
+	p, ok := errors.Lookup(code)
+	if !ok {
+		return info
+	}
+	info.Metadata = map[string]string{"path": code.String()}
+	if f, ok := errors.LookupFormat(code.GetType()); ok && f.Layout != nil {
+		for _, field := range f.Layout.Fields {
+			info.Metadata[field.Key()] = p.Fields[field.Name]
+		}
+	}
+	return info
+}
+
+// ToStatus converts err to a gRPC status. Errors carrying a code get the
+// code's canonical code and an ErrorInfo detail; the status message is
+// the error message without the code. Other errors are converted with
+// status.Convert.
+func ToStatus(err error) *status.Status {
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e.Code == nil {
+		return status.Convert(err)
+	}
+
+	msg := e.Message
+	if e.Cause != nil {
+		if msg != "" {
+			msg += ": "
+		}
+		msg += e.Cause.Error()
+	}
+	st := status.New(Code(e.Code), msg)
+
+	info := ErrorInfo(e.Code)
+	if info == nil {
+		return st
+	}
+	if withInfo, detailErr := st.WithDetails(info); detailErr == nil {
+		st = withInfo
+	}
+	return st
+}
+
+// FromStatus recovers the coded error from a status created by ToStatus.
+// It returns false if the status has no ErrorInfo detail with a decodable
+// code as reason and the domain ToStatus uses for that code; ErrorInfo
+// details of other domains are ignored.
+func FromStatus(st *status.Status) (*errors.Error, bool) {
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		code, err := errors.Decode(info.Reason)
+		if err != nil || info.Domain != domain(code) {
+			continue
+		}
+		return &errors.Error{Code: code, Message: st.Message()}, true
+	}
+	return nil, false
+}
+
+// FromError recovers the coded error from an error returned by a gRPC call
+func FromError(err error) (*errors.Error, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	return FromStatus(st)
+}
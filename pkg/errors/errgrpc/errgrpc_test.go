@@ -0,0 +1,138 @@
+package errgrpc
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func TestCode(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = append(saved[:len(saved):len(saved)],
+			errors.TinyErrorInfo{Value: 100, Name: "upstream_error", HTTPStatus: 502})
+	})
+
+	tests := []struct {
+		name string
+		code errors.ErrorType
+		want codes.Code
+	}{
+		{"validation error", errors.SimpleAPIValidationError, codes.InvalidArgument},
+		{"authorization error", errors.SimpleAPIAuthorizationError, codes.PermissionDenied},
+		{"not found", errors.TinyNotFound, codes.NotFound},
+		{"timeout", errors.SimpleJobsTimeout, codes.DeadlineExceeded},
+		{"HTTP status in catalog", errors.TinyCode{ErrType: 100}, codes.Unavailable},
+		{"no default", errors.SimpleJobsDatabaseQuery, codes.Unknown},
+		{"bad request", errors.TinyBadRequest, codes.InvalidArgument},
+		{"not in catalog", errors.SimpleCode{Class: 9, ErrType: 9}, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.code); got != tt.want {
+				t.Errorf("Code(%v) = %v; want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	err := errors.Wrap(stderrors.New("duplicate key"), errors.BackendHandlerUsersValidationError, "email taken")
+	st := ToStatus(err)
+
+	if st.Code() != codes.InvalidArgument || st.Message() != "email taken: duplicate key" {
+		t.Errorf("ToStatus() = %v %q", st.Code(), st.Message())
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("ToStatus() details = %v; want one ErrorInfo", st.Details())
+	}
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	if info.Reason != "EA0MTXD" || info.Domain != "backend" {
+		t.Errorf("ErrorInfo reason, domain = %q, %q", info.Reason, info.Domain)
+	}
+	wantMetadata := map[string]string{
+		"app":           "backend",
+		"component":     "handler",
+		"sub_component": "users",
+		"error_type":    "validation_error",
+		"path":          "backend.handler.users.validation_error",
+	}
+	for k, v := range wantMetadata {
+		if info.Metadata[k] != v {
+			t.Errorf("metadata[%q] = %q; want %q", k, info.Metadata[k], v)
+		}
+	}
+
+	if got := ToStatus(errors.New(errors.SimpleAPIValidationError, "")); got.Details()[0].(*errdetails.ErrorInfo).Domain != "simple" {
+		t.Error("codes of formats without apps should use the format name as domain")
+	}
+}
+
+func TestErrorInfoNotInCatalog(t *testing.T) {
+	code := errors.SimpleCode{Class: 9, ErrType: 9}
+	st := ToStatus(errors.New(code, "unexpected"))
+	if len(st.Details()) != 1 {
+		t.Fatalf("ToStatus() details = %v; want one ErrorInfo", st.Details())
+	}
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	if info.Reason != code.Encode() || info.Domain != "simple" || len(info.Metadata) != 0 {
+		t.Errorf("ErrorInfo = %v; want reason %s, domain simple and no metadata", info, code.Encode())
+	}
+
+	got, ok := FromStatus(st)
+	if !ok || got.Code != code {
+		t.Errorf("FromStatus() = %v, %v; want code %v", got, ok, code)
+	}
+}
+
+func TestFromStatusDomain(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "bad").WithDetails(&errdetails.ErrorInfo{
+		Reason: "EA0MTXD",
+		Domain: "payments.example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := FromStatus(st); ok {
+		t.Errorf("FromStatus() = %v; want ErrorInfo of another domain to be ignored", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	err := errors.New(errors.BackendHandlerUsersValidationError, "email is required")
+
+	got, ok := FromError(ToStatus(err).Err())
+	if !ok {
+		t.Fatal("FromError() found no code")
+	}
+	code, ok := got.Code.(errors.AppComponentErrorCode)
+	if !ok || code != errors.BackendHandlerUsersValidationError {
+		t.Errorf("recovered code = %#v; want %#v", got.Code, errors.BackendHandlerUsersValidationError)
+	}
+	if got.Error() != err.Error() {
+		t.Errorf("recovered error = %q; want %q", got, err)
+	}
+	if !stderrors.Is(got, errors.BackendHandlerUsersValidationError) {
+		t.Error("recovered error should match its code")
+	}
+}
+
+func TestPlainErrors(t *testing.T) {
+	st := ToStatus(stderrors.New("boom"))
+	if st.Code() != codes.Unknown || len(st.Details()) != 0 {
+		t.Errorf("ToStatus(plain) = %v %v", st.Code(), st.Details())
+	}
+	if _, ok := FromStatus(status.New(codes.NotFound, "missing")); ok {
+		t.Error("FromStatus should not find a code in a status without ErrorInfo")
+	}
+	if _, ok := FromError(stderrors.New("boom")); ok {
+		t.Error("FromError should not find a code in a non-status error")
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/thommeo/error-code-design/pkg/errors/errgrpc
+
+go 1.22.4
+
+require (
+	github.com/thommeo/error-code-design v0.0.0-20261016235215-da07a1862c86
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237
+	google.golang.org/grpc v1.64.1
+)
+
+require (
+	golang.org/x/sys v0.30.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+)
@@ -0,0 +1,270 @@
+// Package errhttp writes errors carrying catalog codes as RFC 9457 problem
+// details responses:
+//
+//	{
+//	  "type": "https://docs.example.com/errors/E10075",
+//	  "title": "API validation error",
+//	  "status": 400,
+//	  "detail": "Some of the entered data is invalid.",
+//	  "code": "E10075",
+//	  "path": "api.validation_error"
+//	}
+//
+// The status is taken from the catalog entry or derived from the name of
+// the error type. Problems are of type "about:blank" unless a docs base
+// URL is given, either as the WithDocsBaseURL option of the package
+// functions or as the DocsBaseURL of a Writer; the type then links the code
+// documentation.
+package errhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// ContentType is the media type of problem details responses
+const ContentType = "application/problem+json"
+
+// Writer writes problem details responses. The zero value is ready to use.
+type Writer struct {
+	// DocsBaseURL is prefixed to the encoded code to build the type URI of
+	// a problem. If empty, the type is "about:blank".
+	DocsBaseURL string
+}
+
+// Option configures the Writer used by the package functions
+type Option func(*Writer)
+
+// WithDocsBaseURL sets the DocsBaseURL of the Writer, e.g.
+// "https://docs.example.com/errors/"
+func WithDocsBaseURL(base string) Option {
+	return func(pw *Writer) {
+		pw.DocsBaseURL = base
+	}
+}
+
+// newWriter returns a Writer configured with opts
+func newWriter(opts []Option) Writer {
+	var pw Writer
+	for _, opt := range opts {
+		opt(&pw)
+	}
+	return pw
+}
+
+// Problem is an RFC 9457 problem details object with the code and its
+// dotted path as extension members
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Path     string `json:"path,omitempty"`
+}
+
+// nameStatuses maps error type names to the status used when the catalog
+// entry does not configure one. A name matches if it equals the key or ends
+// with an underscore followed by the key.
+var nameStatuses = []struct {
+	name   string
+	status int
+}{
+	{"bad_request", http.StatusBadRequest},
+	{"validation", http.StatusBadRequest},
+	{"validation_error", http.StatusBadRequest},
+	{"unauthorized", http.StatusUnauthorized},
+	{"unauthenticated", http.StatusUnauthorized},
+	{"forbidden", http.StatusForbidden},
+	{"authorization_error", http.StatusForbidden},
+	{"permission_denied", http.StatusForbidden},
+	{"not_found", http.StatusNotFound},
+	{"conflict", http.StatusConflict},
+	{"already_exists", http.StatusConflict},
+	{"rate_limited", http.StatusTooManyRequests},
+	{"unavailable", http.StatusServiceUnavailable},
+	{"timeout", http.StatusGatewayTimeout},
+}
+
+// StatusForName returns the default status for an error type name, or 500
+// if the name has no default
+func StatusForName(name string) int {
+	for _, ns := range nameStatuses {
+		if name == ns.name || strings.HasSuffix(name, "_"+ns.name) {
+			return ns.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// Status returns the HTTP status of a code: the status configured in its
+// catalog entry, else the default for its error type name. Codes missing
+// from the catalog get 500.
+func Status(code errors.ErrorType) int {
+	p, ok := errors.Lookup(code)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	if p.HTTPStatus != 0 {
+		return p.HTTPStatus
+	}
+	path := code.String()
+	return StatusForName(path[strings.LastIndexByte(path, '.')+1:])
+}
+
+// NewProblem returns the problem details for err with texts in the given
+// locale. Errors without a code known to the catalog become a generic 500
+// problem that does not expose the error message.
+func NewProblem(err error, locale string, opts ...Option) Problem {
+	return newWriter(opts).NewProblem(err, locale)
+}
+
+// NewProblem is like the package function NewProblem, building the type URI
+// from DocsBaseURL
+func (pw Writer) NewProblem(err error, locale string) Problem {
+	internal := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+	}
+
+	code, ok := errors.CodeOf(err)
+	if !ok {
+		return internal
+	}
+	p, ok := errors.Lookup(code)
+	if !ok {
+		return internal
+	}
+
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  p.Description(locale),
+		Status: Status(code),
+		Code:   p.Code,
+		Path:   code.String(),
+	}
+	if pw.DocsBaseURL != "" {
+		problem.Type = pw.DocsBaseURL + p.Code
+	}
+	if len(p.Localization.Messages) > 0 {
+		problem.Detail = p.Message(locale)
+	}
+	return problem
+}
+
+// WriteError writes err as a problem details response with texts in the
+// default locale
+func WriteError(w http.ResponseWriter, err error, opts ...Option) {
+	newWriter(opts).WriteError(w, err)
+}
+
+// WriteError writes err as a problem details response with texts in the
+// default locale
+func (pw Writer) WriteError(w http.ResponseWriter, err error) {
+	Write(w, pw.NewProblem(err, errors.DefaultLocale))
+}
+
+// Write writes a problem details response
+func Write(w http.ResponseWriter, p Problem) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	w.Write(append(body, '\n'))
+}
+
+// HandlerFunc is an HTTP handler returning an error. A non-nil error is
+// written as a problem details response in the locale preferred by the
+// Accept-Language header of the request, unless the handler already started
+// the response; the error is dropped then. Its problems are of type
+// "about:blank"; Handle serves it with options.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	Writer{}.serve(f, w, r)
+}
+
+// Handle returns a handler serving f like HandlerFunc, writing its errors
+// with a Writer configured with opts
+func Handle(f HandlerFunc, opts ...Option) http.Handler {
+	return newWriter(opts).Handler(f)
+}
+
+// Handler returns a handler serving f like HandlerFunc, writing its errors
+// with pw
+func (pw Writer) Handler(f HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pw.serve(f, w, r)
+	})
+}
+
+func (pw Writer) serve(f HandlerFunc, w http.ResponseWriter, r *http.Request) {
+	tw := &trackingWriter{ResponseWriter: w}
+	err := f(tw, r)
+	if err == nil || tw.started {
+		return
+	}
+	p := pw.NewProblem(err, PreferredLocale(r.Header.Get("Accept-Language")))
+	p.Instance = r.URL.Path
+	Write(w, p)
+}
+
+// trackingWriter records whether a handler started the response.
+// Informational 1xx headers do not count.
+type trackingWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *trackingWriter) WriteHeader(status int) {
+	if status >= 200 {
+		w.started = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *trackingWriter) Flush() {
+	w.started = true
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap gives http.ResponseController access to the wrapped writer
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// PreferredLocale returns the language range with the highest quality in an
+// Accept-Language header, or the default locale if there is none
+func PreferredLocale(header string) string {
+	best, bestQ := errors.DefaultLocale, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if tag == "" || tag == "*" || q <= bestQ {
+			continue
+		}
+		best, bestQ = tag, q
+	}
+	return best
+}
@@ -0,0 +1,256 @@
+package errhttp
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// useLocalizedTree gives the api error types of the built-in simple tree
+// German descriptions and end-user messages until the test ends
+func useLocalizedTree(t *testing.T) {
+	saved := errors.SimpleCodeTree
+	t.Cleanup(func() {
+		errors.ReplaceTrees(func() { errors.SimpleCodeTree = saved })
+	})
+
+	texts := map[string]errors.Localization{
+		"validation_error": {
+			Descriptions: map[string]string{"de": "API-Validierungsfehler"},
+			Messages: map[string]string{
+				"de": "Einige der eingegebenen Daten sind ungültig.",
+				"en": "Some of the entered data is invalid.",
+			},
+		},
+		"authorization_error": {
+			Descriptions: map[string]string{"de": "API-Autorisierungsfehler"},
+			Messages: map[string]string{
+				"de": "Sie sind nicht berechtigt, diese Aktion auszuführen.",
+				"en": "You are not allowed to perform this action.",
+			},
+		},
+	}
+	tree := make([]errors.SimpleClassInfo, len(saved))
+	for i, class := range saved {
+		class.ErrorTypes = append([]errors.SimpleErrorInfo(nil), class.ErrorTypes...)
+		if class.Name == "api" {
+			for j := range class.ErrorTypes {
+				if l, ok := texts[class.ErrorTypes[j].Name]; ok {
+					class.ErrorTypes[j].Localization = l
+				}
+			}
+		}
+		tree[i] = class
+	}
+	errors.ReplaceTrees(func() { errors.SimpleCodeTree = tree })
+}
+
+func TestStatus(t *testing.T) {
+	saved := errors.TinyCodeValues
+	defer errors.ReplaceTrees(func() { errors.TinyCodeValues = saved })
+	errors.ReplaceTrees(func() {
+		errors.TinyCodeValues = append(saved[:len(saved):len(saved)],
+			errors.TinyErrorInfo{Value: 100, Name: "upstream_error", HTTPStatus: http.StatusBadGateway})
+	})
+
+	tests := []struct {
+		name string
+		code errors.ErrorType
+		want int
+	}{
+		{"validation error", errors.SimpleAPIValidationError, http.StatusBadRequest},
+		{"authorization error", errors.SimpleAPIAuthorizationError, http.StatusForbidden},
+		{"timeout", errors.SimpleJobsTimeout, http.StatusGatewayTimeout},
+		{"not found", errors.TinyNotFound, http.StatusNotFound},
+		{"configured in catalog", errors.TinyCode{ErrType: 100}, http.StatusBadGateway},
+		{"no default", errors.SimpleJobsDatabaseQuery, http.StatusInternalServerError},
+		{"not in catalog", errors.SimpleCode{Class: 9, ErrType: 9}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Status(tt.code); got != tt.want {
+				t.Errorf("Status(%v) = %d; want %d", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	useLocalizedTree(t)
+	pw := Writer{DocsBaseURL: "https://docs.example.com/errors/"}
+
+	rec := httptest.NewRecorder()
+	err := fmt.Errorf("handling request: %w", errors.New(errors.SimpleAPIValidationError, "email is required"))
+	pw.WriteError(rec, err)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusBadRequest)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q; want %q", ct, ContentType)
+	}
+
+	var got Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	want := Problem{
+		Type:   "https://docs.example.com/errors/E10075",
+		Title:  "API validation error",
+		Status: http.StatusBadRequest,
+		Detail: "Some of the entered data is invalid.",
+		Code:   "E10075",
+		Path:   "api.validation_error",
+	}
+	if got != want {
+		t.Errorf("body = %+v; want %+v", got, want)
+	}
+}
+
+func TestWriteErrorWithoutCode(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, stderrors.New("connection string with password"))
+
+	var got Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	want := Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError}
+	if rec.Code != http.StatusInternalServerError || got != want {
+		t.Errorf("response = %d %+v; want %+v", rec.Code, got, want)
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	useLocalizedTree(t)
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Path == "/ok" {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+		return errors.NewSimpleAPIAuthorizationError("user may not delete invoices")
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusNoContent)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/invoices/42", nil)
+	req.Header.Set("Accept-Language", "fr;q=0.5, de-AT, en;q=0.8")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var got Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if rec.Code != http.StatusForbidden || got.Title != "API-Autorisierungsfehler" || got.Instance != "/invoices/42" {
+		t.Errorf("response = %d %+v", rec.Code, got)
+	}
+}
+
+func TestDocsBaseURLOption(t *testing.T) {
+	docs := WithDocsBaseURL("https://docs.example.com/errors/")
+	failed := errors.NewSimpleAPIAuthorizationError("user may not delete invoices")
+
+	write := httptest.NewRecorder()
+	WriteError(write, failed, docs)
+	serve := httptest.NewRecorder()
+	Handle(func(w http.ResponseWriter, r *http.Request) error {
+		return failed
+	}, docs).ServeHTTP(serve, httptest.NewRequest(http.MethodDelete, "/invoices/42", nil))
+
+	for name, rec := range map[string]*httptest.ResponseRecorder{"WriteError": write, "Handle": serve} {
+		var got Problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: invalid JSON body: %v", name, err)
+		}
+		if got.Type != "https://docs.example.com/errors/E10076" {
+			t.Errorf("%s: type = %q", name, got.Type)
+		}
+	}
+
+	if got := NewProblem(failed, "en", docs).Type; got != "https://docs.example.com/errors/E10076" {
+		t.Errorf("NewProblem type = %q", got)
+	}
+	if got := NewProblem(failed, "en").Type; got != "about:blank" {
+		t.Errorf("NewProblem type without options = %q", got)
+	}
+}
+
+func TestHandlerStartedResponse(t *testing.T) {
+	failed := errors.NewSimpleAPIAuthorizationError("stream interrupted")
+	tests := []struct {
+		name    string
+		handler HandlerFunc
+		status  int
+		body    string
+	}{
+		{"header written", func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusAccepted)
+			return failed
+		}, http.StatusAccepted, ""},
+		{"body written", func(w http.ResponseWriter, r *http.Request) error {
+			w.Write([]byte("partial"))
+			return failed
+		}, http.StatusOK, "partial"},
+		{"flushed", func(w http.ResponseWriter, r *http.Request) error {
+			http.NewResponseController(w).Flush()
+			return failed
+		}, http.StatusOK, ""},
+	}
+
+	pw := Writer{DocsBaseURL: "https://docs.example.com/errors/"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			pw.Handler(tt.handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+			if rec.Code != tt.status || rec.Body.String() != tt.body || rec.Header().Get("Content-Type") == ContentType {
+				t.Errorf("response = %d %q; want %d %q", rec.Code, rec.Body, tt.status, tt.body)
+			}
+		})
+	}
+
+	// Errors of handlers that did not start the response use the writer
+	rec := httptest.NewRecorder()
+	pw.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		w.Header().Set("Cache-Control", "no-store")
+		return failed
+	}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	var got Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if rec.Code != http.StatusForbidden || got.Type != "https://docs.example.com/errors/"+got.Code {
+		t.Errorf("response = %d %+v", rec.Code, got)
+	}
+}
+
+func TestPreferredLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"de", "de"},
+		{"fr;q=0.5, de-AT, en;q=0.8", "de-AT"},
+		{"fr;q=0.5, en;q=0.8", "en"},
+		{"*, de;q=0", "en"},
+		{"de;q=bad, fr;q=0.1", "fr"},
+	}
+
+	for _, tt := range tests {
+		if got := PreferredLocale(tt.header); got != tt.want {
+			t.Errorf("PreferredLocale(%q) = %q; want %q", tt.header, got, tt.want)
+		}
+	}
+}
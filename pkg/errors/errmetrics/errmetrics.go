@@ -0,0 +1,199 @@
+// Package errmetrics counts coded errors by catalog entry and serves the
+// counts in the Prometheus text exposition format:
+//
+//	reg := errmetrics.NewRegistry()
+//	errors.SetObserver(reg.Observe)
+//	http.Handle("/metrics", reg)
+//
+// Series are labeled with the code, its format, one label per field of any
+// registered layout holding the catalog name, and the event. Every series
+// has the same label names; fields the format of a code lacks are empty:
+//
+//	coded_errors_total{code="EA0MTXD",format="app_component",app="backend",class="",component="handler",error_type="validation_error",sub_component="users",event="created"} 3
+//
+// Codes missing from the catalog are counted in one series per format and
+// event with code="other", so the number of series is bounded by the size
+// of the catalog.
+package errmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// MetricName is the name of the counter family
+const MetricName = "coded_errors_total"
+
+// ContentType is the media type of the exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// otherCode is the code label of codes missing from the catalog
+const otherCode = "other"
+
+// Registry holds one counter per catalog code and event. It is safe for
+// concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	series map[seriesKey]*series
+}
+
+type seriesKey struct {
+	format errors.CodeType
+	code   string // Encoded code, empty for codes missing from the catalog
+	event  errors.Event
+}
+
+type series struct {
+	labels string // Rendered label set, taken from the catalog when the series is created
+	count  atomic.Uint64
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{series: map[seriesKey]*series{}}
+}
+
+// Observe counts err under event. It can be passed to errors.SetObserver.
+func (r *Registry) Observe(event errors.Event, err *errors.Error) {
+	r.Add(err.Code, event)
+}
+
+// Add increments the counter of code and event. A nil code is ignored.
+func (r *Registry) Add(code errors.ErrorType, event errors.Event) {
+	if code == nil {
+		return
+	}
+	key, p := seriesKeyOf(code, event)
+
+	r.mu.RLock()
+	s, ok := r.series[key]
+	r.mu.RUnlock()
+	if !ok {
+		r.mu.Lock()
+		if s, ok = r.series[key]; !ok {
+			s = &series{labels: labels(key, p)}
+			r.series[key] = s
+		}
+		r.mu.Unlock()
+	}
+	s.count.Add(1)
+}
+
+// Count returns the number of times code was counted under event. Codes
+// missing from the catalog share the count of their format.
+func (r *Registry) Count(code errors.ErrorType, event errors.Event) uint64 {
+	if code == nil {
+		return 0
+	}
+	key, _ := seriesKeyOf(code, event)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if s, ok := r.series[key]; ok {
+		return s.count.Load()
+	}
+	return 0
+}
+
+// Write writes all counters in the text exposition format, sorted by their
+// labels
+func (r *Registry) Write(w io.Writer) error {
+	type line struct {
+		labels string
+		count  uint64
+	}
+	r.mu.RLock()
+	lines := make([]line, 0, len(r.series))
+	for _, s := range r.series {
+		lines = append(lines, line{s.labels, s.count.Load()})
+	}
+	r.mu.RUnlock()
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].labels < lines[j].labels
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# HELP %s Errors carrying a catalog code, by code and event.\n", MetricName)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", MetricName)
+	for _, l := range lines {
+		fmt.Fprintf(bw, "%s{%s} %d\n", MetricName, l.labels, l.count)
+	}
+	return bw.Flush()
+}
+
+// ServeHTTP serves the counters in the text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	r.Write(w)
+}
+
+// seriesKeyOf returns the key of the series counting code and its catalog
+// entry
+func seriesKeyOf(code errors.ErrorType, event errors.Event) (seriesKey, errors.Permutation) {
+	key := seriesKey{format: code.GetType(), event: event}
+	p, ok := errors.Lookup(code)
+	if ok {
+		key.code = p.Code
+	}
+	return key, p
+}
+
+// labels renders the label set of a series
+func labels(key seriesKey, p errors.Permutation) string {
+	var b strings.Builder
+	label := func(name, value string) {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", name, labelEscaper.Replace(value))
+	}
+
+	f, _ := errors.LookupFormat(key.format)
+	if key.code == "" {
+		label("code", otherCode)
+	} else {
+		label("code", key.code)
+	}
+	label("format", f.Name)
+	values := map[string]string{}
+	if key.code != "" && f.Layout != nil {
+		for _, field := range f.Layout.Fields {
+			values[field.Key()] = p.Fields[field.Name]
+		}
+	}
+	for _, k := range fieldKeys() {
+		label(k, values[k])
+	}
+	label("event", key.event.String())
+	return b.String()
+}
+
+// fieldKeys returns the sorted label names of the fields of all registered
+// layouts
+func fieldKeys() []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, f := range errors.Formats() {
+		if f.Layout == nil {
+			continue
+		}
+		for _, field := range f.Layout.Fields {
+			if k := field.Key(); !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -0,0 +1,100 @@
+package errmetrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func TestObserve(t *testing.T) {
+	reg := NewRegistry()
+	errors.SetObserver(reg.Observe)
+	defer errors.SetObserver(nil)
+
+	err := errors.New(errors.SimpleAPIValidationError, "bad input")
+	errors.Wrap(err, errors.SimpleAPIValidationError, "retry")
+	errors.Report(fmt.Errorf("handler: %w", err))
+	errors.Report(fmt.Errorf("no code"))
+
+	if got := reg.Count(errors.SimpleAPIValidationError, errors.EventCreated); got != 2 {
+		t.Errorf("created count = %d; want 2", got)
+	}
+	if got := reg.Count(errors.SimpleAPIValidationError, errors.EventReported); got != 1 {
+		t.Errorf("reported count = %d; want 1", got)
+	}
+}
+
+func TestUnknownCodesShareSeries(t *testing.T) {
+	reg := NewRegistry()
+	for i := 0; i < 100; i++ {
+		reg.Add(errors.SimpleCode{Class: 200, ErrType: errors.SimpleErrorCode(i)}, errors.EventCreated)
+	}
+	reg.Add(errors.SimpleAPIValidationError, errors.EventCreated)
+
+	if got := reg.Count(errors.SimpleCode{Class: 201}, errors.EventCreated); got != 100 {
+		t.Errorf("count of unknown codes = %d; want 100", got)
+	}
+	if n := len(reg.series); n != 2 {
+		t.Errorf("registry has %d series; want 2", n)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	reg := NewRegistry()
+	reg.Add(errors.BackendHandlerUsersValidationError, errors.EventCreated)
+	reg.Add(errors.BackendHandlerUsersValidationError, errors.EventCreated)
+	reg.Add(errors.BackendHandlerUsersValidationError, errors.EventReported)
+	reg.Add(errors.SimpleAPIValidationError, errors.EventCreated)
+	reg.Add(errors.TinyCode{ErrType: 1000}, errors.EventCreated)
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q; want %q", ct, ContentType)
+	}
+	code := errors.BackendHandlerUsersValidationError.Encode()
+	labels := `code="` + code + `",format="app_component",app="backend",class="",component="handler",error_type="validation_error",sub_component="users"`
+	want := strings.Join([]string{
+		"# HELP coded_errors_total Errors carrying a catalog code, by code and event.",
+		"# TYPE coded_errors_total counter",
+		`coded_errors_total{code="E10075",format="simple",app="",class="api",component="",error_type="validation_error",sub_component="",event="created"} 1`,
+		`coded_errors_total{` + labels + `,event="created"} 2`,
+		`coded_errors_total{` + labels + `,event="reported"} 1`,
+		`coded_errors_total{code="other",format="tiny",app="",class="",component="",error_type="",sub_component="",event="created"} 1`,
+		"",
+	}, "\n")
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	reg := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				reg.Add(errors.SimpleJobsTimeout, errors.EventCreated)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := reg.Count(errors.SimpleJobsTimeout, errors.EventCreated); got != 8000 {
+		t.Errorf("count = %d; want 8000", got)
+	}
+}
+
+func TestLabelEscaping(t *testing.T) {
+	if got := labelEscaper.Replace("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("escaped = %s", got)
+	}
+}
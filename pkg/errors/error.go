@@ -0,0 +1,109 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+)
+
+// Error is a runtime error carrying a catalog code, a message and an
+// optional cause
+type Error struct {
+	Code    ErrorType
+	Message string
+	Cause   error
+}
+
+// New returns an error with the given code and message
+func New(code ErrorType, message string) *Error {
+	err := &Error{Code: code, Message: message}
+	checkDeprecated(err)
+	observe(EventCreated, err)
+	return err
+}
+
+// Newf returns an error with the given code and a formatted message
+func Newf(code ErrorType, format string, args ...any) *Error {
+	return New(code, fmt.Sprintf(format, args...))
+}
+
+// Wrap returns an error with the given code and message wrapping cause
+func Wrap(cause error, code ErrorType, message string) *Error {
+	err := &Error{Code: code, Message: message, Cause: cause}
+	checkDeprecated(err)
+	observe(EventCreated, err)
+	return err
+}
+
+func (e *Error) Error() string {
+	msg := codeMessage(e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a code equal to the code of e, so that
+// errors.Is(err, code) matches through wrapped chains
+func (e *Error) Is(target error) bool {
+	code, ok := target.(ErrorType)
+	return ok && sameCode(e.Code, code)
+}
+
+// As assigns the code of e to target when target points to a value of the
+// code's type or to an interface it implements
+func (e *Error) As(target any) bool {
+	if e.Code == nil {
+		return false
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return false
+	}
+	code := reflect.ValueOf(e.Code)
+	if !code.Type().AssignableTo(v.Elem().Type()) {
+		return false
+	}
+	v.Elem().Set(code)
+	return true
+}
+
+// CodeOf returns the code of the first *Error found in the chain of err
+func CodeOf(err error) (ErrorType, bool) {
+	var e *Error
+	if !stderrors.As(err, &e) || e.Code == nil {
+		return nil, false
+	}
+	return e.Code, true
+}
+
+// codeMessage formats a code as "<encoded> <name>" for error messages
+func codeMessage(code ErrorType) string {
+	if code == nil {
+		return "<nil code>"
+	}
+	encoded, err := EncodeChecked(code)
+	if err != nil {
+		return fmt.Sprintf("<%v> %s", err, code.String())
+	}
+	return fmt.Sprintf("%s %s", encoded, code.String())
+}
+
+func sameCode(a, b ErrorType) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.GetType() != b.GetType() {
+		return false
+	}
+	encodedA, errA := EncodeChecked(a)
+	encodedB, errB := EncodeChecked(b)
+	return errA == nil && errB == nil && encodedA == encodedB
+}
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorMessage(t *testing.T) {
+	code := SimpleCode{Class: 1, ErrType: 1}
+	cause := stderrors.New("missing field")
+	err := Wrap(cause, code, "bad input")
+
+	want := fmt.Sprintf("%s api.validation_error: bad input: missing field", code.Encode())
+	if err.Error() != want {
+		t.Errorf("Error() = %q; want %q", err.Error(), want)
+	}
+	if stderrors.Unwrap(err) != cause {
+		t.Errorf("Unwrap() = %v; want %v", stderrors.Unwrap(err), cause)
+	}
+}
+
+// Codes implement error, so fmt prints them through Error rather than
+// String
+func TestCodeFormatting(t *testing.T) {
+	tests := []struct {
+		code ErrorType
+		want string
+		path string
+	}{
+		{TinyCode{ErrType: 2}, "E002 not_found", "not_found"},
+		{SimpleCode{Class: 1, ErrType: 1}, "E10075 api.validation_error", "api.validation_error"},
+		{BackendHandlerUsersValidationError, "EA0MTXD backend.handler.users.validation_error", "backend.handler.users.validation_error"},
+		{Simple511Code{Class: 1, ErrType: 4}, "E301L0 http.not_found", "http.not_found"},
+		{Simple511Code{Class: 32}, "<value overflows field: Class value 32 exceeds maximum of 31> invalid", "invalid"},
+	}
+	for _, tt := range tests {
+		for _, verb := range []string{"%v", "%s"} {
+			if got := fmt.Sprintf(verb, tt.code); got != tt.want {
+				t.Errorf("Sprintf(%q, %#v) = %q; want %q", verb, tt.code, got, tt.want)
+			}
+		}
+		if got := tt.code.String(); got != tt.path {
+			t.Errorf("%#v.String() = %q; want %q", tt.code, got, tt.path)
+		}
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	code := AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 1}
+	err := fmt.Errorf("handling request: %w", New(code, "bad email"))
+
+	if !stderrors.Is(err, code) {
+		t.Error("errors.Is should match the code through a wrapped chain")
+	}
+	if stderrors.Is(err, AppComponentErrorCode{App: 1, Component: 1, SubComponent: 1, ErrType: 2}) {
+		t.Error("errors.Is should not match a different code")
+	}
+	if stderrors.Is(err, SimpleCode{Class: 1, ErrType: 1}) {
+		t.Error("errors.Is should not match a code of another format")
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	code := Simple511Code{Class: 1, ErrType: 4}
+	err := fmt.Errorf("fetching record: %w", New(code, "record 42"))
+
+	var got Simple511Code
+	if !stderrors.As(err, &got) || got != code {
+		t.Errorf("errors.As(Simple511Code) = %v; want %v", got, code)
+	}
+
+	var wrongType TinyCode
+	if stderrors.As(err, &wrongType) {
+		t.Error("errors.As should not match a code of another format")
+	}
+
+	var anyCode ErrorType
+	if !stderrors.As(err, &anyCode) || anyCode != code {
+		t.Errorf("errors.As(ErrorType) = %v; want %v", anyCode, code)
+	}
+
+	if got, ok := CodeOf(err); !ok || got != code {
+		t.Errorf("CodeOf() = %v, %v; want %v", got, ok, code)
+	}
+	if _, ok := CodeOf(stderrors.New("plain")); ok {
+		t.Error("CodeOf should not find a code in a plain error")
+	}
+}
@@ -0,0 +1,228 @@
+// Package errscan finds error codes in free text such as log files.
+//
+// A candidate is a word starting with E followed by base36 characters. It
+// is reported if its length matches the format named by its type
+// character, it decodes, and, unless IncludeUnknown is set, its code is in
+// the catalog. Words are delimited by any character other than letters,
+// digits and underscores, so codes embedded in longer identifiers are not
+// matched.
+package errscan
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"maps"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// Match is a code found in the text
+type Match struct {
+	Code   errors.ErrorType  `json:"-"`
+	Text   string            `json:"code"`   // The code as found in the text
+	Offset int64             `json:"offset"` // Byte offset from the start of the input
+	Line   int               `json:"line"`   // 1-based line number
+	Column int               `json:"column"` // 1-based byte column within the line
+	Format string            `json:"format"`
+	Path   string            `json:"path,omitempty"` // Empty if the code is not in the catalog
+	Fields map[string]uint32 `json:"fields,omitempty"`
+	Known  bool              `json:"known"`
+}
+
+// Scanner reads text line by line and reports the codes found in each
+// line, in the manner of bufio.Scanner
+type Scanner struct {
+	// IncludeUnknown also reports codes that decode but are missing from
+	// the catalog, e.g. retired codes in old logs. It must be set before
+	// the first call to Scan.
+	IncludeUnknown bool
+
+	r       *bufio.Reader
+	line    []byte
+	lineNo  int
+	offset  int64 // Offset of the current line
+	next    int64 // Offset of the next line
+	matches []Match
+	buf     []byte // Lines longer than the read buffer
+	cache   map[string]cached
+	err     error
+}
+
+// cached is the result of matching a candidate word
+type cached struct {
+	m  Match
+	ok bool
+}
+
+// maxCached bounds the number of cached candidate words
+const maxCached = 4096
+
+// NewScanner returns a scanner reading from r
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Scan advances to the next line, which is then available through Bytes
+// and Matches. It returns false at the end of the input or on an error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	line, err := s.readLine()
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		if err != io.EOF {
+			s.err = err
+		}
+		s.line, s.matches = nil, nil
+		return false
+	}
+
+	s.line = line
+	s.lineNo++
+	s.offset = s.next
+	s.next += int64(len(line))
+	s.matches = s.find(line)
+	return true
+}
+
+// readLine reads up to and including the next newline without copying
+// lines that fit into the read buffer
+func (s *Scanner) readLine() ([]byte, error) {
+	line, err := s.r.ReadSlice('\n')
+	if err != bufio.ErrBufferFull {
+		return line, err
+	}
+	s.buf = append(s.buf[:0], line...)
+	for err == bufio.ErrBufferFull {
+		line, err = s.r.ReadSlice('\n')
+		s.buf = append(s.buf, line...)
+	}
+	return s.buf, err
+}
+
+// Bytes returns the current line including its line terminator. The slice
+// is only valid until the next call to Scan.
+func (s *Scanner) Bytes() []byte {
+	return s.line
+}
+
+// Matches returns the codes found in the current line
+func (s *Scanner) Matches() []Match {
+	return s.matches
+}
+
+// Err returns the first non-EOF error encountered while reading
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// find returns the codes in line
+func (s *Scanner) find(line []byte) []Match {
+	var matches []Match
+	for i := 0; i < len(line); i++ {
+		j := bytes.IndexByte(line[i:], 'E')
+		if j < 0 {
+			break
+		}
+		i += j
+		if i > 0 && isWordChar(line[i-1]) {
+			continue
+		}
+		end := i + 1
+		for end < len(line) && isCodeChar(line[end]) {
+			end++
+		}
+		if end < len(line) && isWordChar(line[end]) {
+			i = end
+			continue
+		}
+		if m, ok := s.match(string(line[i:end])); ok {
+			m.Offset = s.offset + int64(i)
+			m.Line = s.lineNo
+			m.Column = i + 1
+			matches = append(matches, m)
+		}
+		i = end - 1
+	}
+	return matches
+}
+
+// match decodes a candidate word. Results are cached since logs repeat the
+// same few codes; each match gets its own copy of the Fields map.
+func (s *Scanner) match(text string) (Match, bool) {
+	if c, ok := s.cache[text]; ok {
+		m := c.m
+		m.Fields = maps.Clone(m.Fields)
+		return m, c.ok
+	}
+	m, ok := s.decode(text)
+	if s.cache == nil {
+		s.cache = map[string]cached{}
+	}
+	if len(s.cache) < maxCached {
+		s.cache[text] = cached{m, ok}
+		m.Fields = maps.Clone(m.Fields)
+	}
+	return m, ok
+}
+
+// decode decodes a candidate word without caching
+func (s *Scanner) decode(text string) (Match, bool) {
+	f, ok := errors.LookupFormatCode(text)
+	if !ok || len(text) != f.Length() {
+		return Match{}, false
+	}
+	code, err := f.Decode(text)
+	if err != nil {
+		return Match{}, false
+	}
+	_, known := errors.Lookup(code)
+	if !known && !s.IncludeUnknown {
+		return Match{}, false
+	}
+
+	m := Match{Code: code, Text: text, Format: f.Name, Known: known}
+	if known {
+		m.Path = code.String()
+	}
+	if f.Layout != nil {
+		if values, err := f.Layout.Decode(text); err == nil {
+			m.Fields = make(map[string]uint32, len(values))
+			for i, field := range f.Layout.Fields {
+				m.Fields[naming.FieldKey(field.Name)] = values[i]
+			}
+		}
+	}
+	return m, true
+}
+
+func isCodeChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z'
+}
+
+func isWordChar(c byte) bool {
+	return isCodeChar(c) || c >= 'a' && c <= 'z' || c == '_'
+}
+
+// Annotate returns line with the catalog path of each known match inserted
+// in brackets after the code, e.g. "EA0MTXD[backend.handler.users.validation_error]"
+func Annotate(line []byte, matches []Match) []byte {
+	if len(matches) == 0 {
+		return line
+	}
+	var b []byte
+	last := 0
+	for _, m := range matches {
+		end := m.Column - 1 + len(m.Text)
+		b = append(b, line[last:end]...)
+		if m.Known {
+			b = append(b, '[')
+			b = append(b, m.Path...)
+			b = append(b, ']')
+		}
+		last = end
+	}
+	return append(b, line[last:]...)
+}
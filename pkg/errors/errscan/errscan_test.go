@@ -0,0 +1,124 @@
+package errscan
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+var (
+	validation = errors.BackendHandlerUsersValidationError.Encode()
+	timeout    = errors.SimpleJobsTimeout.Encode()
+	unknown    = errors.AppComponentErrorCode{App: 9, Component: 9}.Encode()
+)
+
+func scanAll(t *testing.T, s *Scanner) (lines []string, matches []Match) {
+	t.Helper()
+	for s.Scan() {
+		lines = append(lines, string(s.Bytes()))
+		matches = append(matches, s.Matches()...)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	return lines, matches
+}
+
+func TestScanner(t *testing.T) {
+	input := "level=error code=" + validation + " msg=\"bad input\"\n" +
+		"ERROR job failed: " + timeout + ", retrying (" + validation + ")\n" +
+		"no codes here, EVERYTHING is E1 fine\n" +
+		"unknown " + unknown + " and embedded x" + validation + " or " + validation + "x or " + validation + "0"
+
+	lines, matches := scanAll(t, NewScanner(strings.NewReader(input)))
+	if len(lines) != 4 {
+		t.Errorf("scanned %d lines; want 4", len(lines))
+	}
+
+	want := []struct {
+		text   string
+		line   int
+		column int
+	}{
+		{validation, 1, 18},
+		{timeout, 2, 19},
+		{validation, 2, 37},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("found %d matches; want %d: %+v", len(matches), len(want), matches)
+	}
+	for i, w := range want {
+		m := matches[i]
+		if m.Text != w.text || m.Line != w.line || m.Column != w.column {
+			t.Errorf("match %d = %s at %d:%d; want %s at %d:%d", i, m.Text, m.Line, m.Column, w.text, w.line, w.column)
+		}
+		if input[m.Offset:m.Offset+int64(len(m.Text))] != m.Text {
+			t.Errorf("match %d offset %d does not point at the code", i, m.Offset)
+		}
+	}
+
+	if m := matches[0]; m.Path != "backend.handler.users.validation_error" || !m.Known || m.Format != "app_component" || m.Fields["sub_component"] != 1 {
+		t.Errorf("match 0 = %+v", m)
+	}
+	if matches[0].Code != errors.BackendHandlerUsersValidationError {
+		t.Errorf("match 0 code = %v", matches[0].Code)
+	}
+}
+
+func TestScannerFieldsCopied(t *testing.T) {
+	_, matches := scanAll(t, NewScanner(strings.NewReader(validation+" "+validation+"\n"+validation)))
+	if len(matches) != 3 {
+		t.Fatalf("found %d matches; want 3", len(matches))
+	}
+	matches[0].Fields["sub_component"] = 99
+	matches[1].Fields["sub_component"] = 98
+	if got := matches[2].Fields["sub_component"]; got != 1 {
+		t.Errorf("sub_component of match 2 = %d after changing the others; want 1", got)
+	}
+}
+
+func TestScannerIncludeUnknown(t *testing.T) {
+	s := NewScanner(strings.NewReader("retired " + unknown + "\n"))
+	s.IncludeUnknown = true
+	_, matches := scanAll(t, s)
+
+	if len(matches) != 1 || matches[0].Known || matches[0].Path != "" || matches[0].Fields["app"] != 9 {
+		t.Errorf("matches = %+v; want the unknown code", matches)
+	}
+}
+
+func TestScannerReadError(t *testing.T) {
+	s := NewScanner(iotest.TimeoutReader(strings.NewReader(validation + "\n")))
+	for s.Scan() {
+	}
+	if s.Err() == nil {
+		t.Error("Err() = nil; want the read error")
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	s := NewScanner(strings.NewReader("a " + validation + " b " + timeout + " c " + unknown + "\n"))
+	s.IncludeUnknown = true
+	if !s.Scan() {
+		t.Fatal("Scan() returned false")
+	}
+
+	got := string(Annotate(s.Bytes(), s.Matches()))
+	want := "a " + validation + "[backend.handler.users.validation_error] b " + timeout + "[jobs.timeout] c " + unknown + "\n"
+	if got != want {
+		t.Errorf("Annotate() = %q; want %q", got, want)
+	}
+}
+
+func BenchmarkScanner(b *testing.B) {
+	line := "2024-05-01T12:00:00Z level=error msg=\"request failed\" code=" + validation + " user=42 ERROR\n"
+	input := strings.Repeat(line, 1000)
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		s := NewScanner(strings.NewReader(input))
+		for s.Scan() {
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package errscan
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+// Stats counts code occurrences per code, per tree level and per time
+// bucket
+type Stats struct {
+	bucket  time.Duration
+	total   int
+	codes   map[string]*Count
+	levels  map[levelKey]int
+	buckets map[int64]int // By bucket start in Unix nanoseconds
+}
+
+// levelKey is a node of a format's tree, e.g. the component
+// "backend.handler" of the app_component format
+type levelKey struct {
+	format errors.CodeType
+	field  int
+	path   string
+}
+
+// Count is the number of occurrences of a code or tree node
+type Count struct {
+	Name  string `json:"name"`           // Dotted path, empty for codes missing from the catalog
+	Code  string `json:"code,omitempty"` // Set for codes only
+	Count int    `json:"count"`
+}
+
+// LevelCounts are the occurrences rolled up to one level of a format's
+// tree, such as the app or component level
+type LevelCounts struct {
+	Format string  `json:"format"`
+	Level  string  `json:"level"` // Layout field name in snake case, e.g. "sub_component"
+	Counts []Count `json:"counts"`
+}
+
+// Bucket is the number of occurrences in a time interval
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// Report is a summary of the collected statistics
+type Report struct {
+	Total     int           `json:"total"`
+	Distinct  int           `json:"distinct"` // Number of distinct codes
+	Codes     []Count       `json:"codes"`
+	Levels    []LevelCounts `json:"levels"`
+	Bucket    string        `json:"bucket,omitempty"` // Width of the histogram buckets
+	Histogram []Bucket      `json:"histogram,omitempty"`
+}
+
+// NewStats returns empty statistics with histogram buckets of the given
+// width
+func NewStats(bucket time.Duration) *Stats {
+	return &Stats{
+		bucket:  bucket,
+		codes:   map[string]*Count{},
+		levels:  map[levelKey]int{},
+		buckets: map[int64]int{},
+	}
+}
+
+// Add counts an occurrence of code at time t. A zero t leaves the
+// occurrence out of the histogram.
+func (s *Stats) Add(code errors.ErrorType, t time.Time) {
+	encoded, err := errors.EncodeChecked(code)
+	if err != nil {
+		return
+	}
+	s.total++
+
+	c, ok := s.codes[encoded]
+	if !ok {
+		c = &Count{Code: encoded}
+		if _, known := errors.Lookup(code); known {
+			c.Name = code.String()
+		}
+		s.codes[encoded] = c
+	}
+	c.Count++
+
+	// Roll up to every level above the error type
+	if c.Name != "" {
+		segments := strings.Split(c.Name, ".")
+		for i := 0; i < len(segments)-1; i++ {
+			s.levels[levelKey{code.GetType(), i, strings.Join(segments[:i+1], ".")}]++
+		}
+	}
+
+	if !t.IsZero() && s.bucket > 0 {
+		s.buckets[t.Truncate(s.bucket).UnixNano()]++
+	}
+}
+
+// Report returns the counts sorted by descending count, limiting each table
+// to the top entries if top is positive
+func (s *Stats) Report(top int) Report {
+	r := Report{Total: s.total, Distinct: len(s.codes), Codes: []Count{}, Levels: []LevelCounts{}}
+	for _, c := range s.codes {
+		r.Codes = append(r.Codes, *c)
+	}
+	r.Codes = topCounts(r.Codes, top)
+
+	for _, f := range errors.Formats() {
+		if f.Layout == nil {
+			continue
+		}
+		for field := 0; field < len(f.Layout.Fields)-1; field++ {
+			var counts []Count
+			for key, n := range s.levels {
+				if key.format == f.Type() && key.field == field {
+					counts = append(counts, Count{Name: key.path, Count: n})
+				}
+			}
+			if len(counts) > 0 {
+				r.Levels = append(r.Levels, LevelCounts{
+					Format: f.Name,
+					Level:  naming.FieldKey(f.Layout.Fields[field].Name),
+					Counts: topCounts(counts, top),
+				})
+			}
+		}
+	}
+
+	if len(s.buckets) > 0 {
+		r.Bucket = s.bucket.String()
+		for start, n := range s.buckets {
+			r.Histogram = append(r.Histogram, Bucket{Start: time.Unix(0, start).UTC(), Count: n})
+		}
+		sort.Slice(r.Histogram, func(i, j int) bool {
+			return r.Histogram[i].Start.Before(r.Histogram[j].Start)
+		})
+	}
+	return r
+}
+
+// topCounts sorts counts by descending count, then by code and name, and
+// keeps the first top entries
+func topCounts(counts []Count, top int) []Count {
+	sort.Slice(counts, func(i, j int) bool {
+		a, b := counts[i], counts[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		return a.Name < b.Name
+	})
+	if top > 0 && len(counts) > top {
+		counts = counts[:top]
+	}
+	return counts
+}
@@ -0,0 +1,63 @@
+package errscan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thommeo/error-code-design/pkg/errors"
+)
+
+func TestStats(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewStats(time.Hour)
+
+	s.Add(errors.BackendHandlerUsersValidationError, start.Add(5*time.Minute))
+	s.Add(errors.BackendHandlerUsersValidationError, start.Add(65*time.Minute))
+	s.Add(errors.BackendHandlerUsersAuthorizationError, start.Add(70*time.Minute))
+	s.Add(errors.BackendJobSyncTimeout, time.Time{})
+	s.Add(errors.SimpleJobsTimeout, start)
+	s.Add(errors.AppComponentErrorCode{App: 9}, start)
+
+	r := s.Report(2)
+	if r.Total != 6 || r.Distinct != 5 {
+		t.Errorf("Total, Distinct = %d, %d; want 6, 5", r.Total, r.Distinct)
+	}
+	wantCode := Count{Name: "backend.handler.users.validation_error", Code: errors.BackendHandlerUsersValidationError.Encode(), Count: 2}
+	if len(r.Codes) != 2 || r.Codes[0] != wantCode {
+		t.Errorf("Codes = %+v; want top 2 starting with %+v", r.Codes, wantCode)
+	}
+
+	levels := map[string][]Count{}
+	for _, l := range r.Levels {
+		levels[l.Format+"/"+l.Level] = l.Counts
+	}
+	if got := levels["app_component/app"]; len(got) != 1 || got[0] != (Count{Name: "backend", Count: 4}) {
+		t.Errorf("app level = %+v; want backend 4, unknown codes left out", got)
+	}
+	if got := levels["app_component/component"]; len(got) != 2 || got[0] != (Count{Name: "backend.handler", Count: 3}) {
+		t.Errorf("component level = %+v", got)
+	}
+	if got := levels["app_component/sub_component"]; len(got) != 2 || got[0].Name != "backend.handler.users" {
+		t.Errorf("sub_component level = %+v", got)
+	}
+	if got := levels["simple/class"]; len(got) != 1 || got[0] != (Count{Name: "jobs", Count: 1}) {
+		t.Errorf("class level = %+v", got)
+	}
+
+	wantHist := []Bucket{{start, 3}, {start.Add(time.Hour), 2}}
+	if r.Bucket != "1h0m0s" || len(r.Histogram) != len(wantHist) {
+		t.Fatalf("Histogram = %s %+v; want %+v", r.Bucket, r.Histogram, wantHist)
+	}
+	for i, b := range wantHist {
+		if !r.Histogram[i].Start.Equal(b.Start) || r.Histogram[i].Count != b.Count {
+			t.Errorf("bucket %d = %+v; want %+v", i, r.Histogram[i], b)
+		}
+	}
+}
+
+func TestStatsEmpty(t *testing.T) {
+	r := NewStats(time.Hour).Report(10)
+	if r.Total != 0 || r.Codes == nil || r.Levels == nil || r.Histogram != nil {
+		t.Errorf("Report() = %+v; want empty tables and no histogram", r)
+	}
+}
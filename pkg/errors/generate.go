@@ -0,0 +1,6 @@
+package errors
+
+// The code trees are generated from the built-in catalog first, as the
+// code constants are named after them
+//go:generate go run ../../cmd/treegen -o catalog_gen.go
+//go:generate go run ../../cmd/constgen -o codes_gen.go
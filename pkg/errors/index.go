@@ -0,0 +1,231 @@
+package errors
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// indexEntry is a catalog code with the data returned by String and Lookup
+type indexEntry struct {
+	key  uint32 // Packed field values
+	name string // Dotted path returned by String
+	perm Permutation
+}
+
+// catalogIndex is the immutable index of the codes of one tree
+type catalogIndex struct {
+	entries []indexEntry // In tree order
+	byKey   map[uint32]*indexEntry
+	err     error // First entry that could not be encoded
+}
+
+// add encodes the field values of a tree leaf and appends its entry, filling
+// in the type and code of perm and prepending the code to its table fields.
+// Leaves that do not fit into the layout are left out and recorded as err.
+func (idx *catalogIndex) add(l Layout, name string, perm Permutation, values ...uint32) {
+	key, err := l.Pack(values...)
+	if err == nil {
+		perm.Code, err = l.Encode(values...)
+	}
+	if err != nil {
+		if idx.err == nil {
+			idx.err = fmt.Errorf("%s: %w", name, err)
+		}
+		return
+	}
+	perm.Type = l.Type
+	perm.TableFields = append([]string{perm.Code}, perm.TableFields...)
+	idx.entries = append(idx.entries, indexEntry{key: key, name: name, perm: perm})
+}
+
+// finish builds the key map once all entries are added. The first entry
+// with a key wins, as a linear scan of the tree would.
+func (idx *catalogIndex) finish() *catalogIndex {
+	idx.byKey = make(map[uint32]*indexEntry, len(idx.entries))
+	for i := range idx.entries {
+		if _, ok := idx.byKey[idx.entries[i].key]; !ok {
+			idx.byKey[idx.entries[i].key] = &idx.entries[i]
+		}
+	}
+	return idx
+}
+
+// lookup returns the entry of the code with the given field values
+func (idx *catalogIndex) lookup(l Layout, values ...uint32) (*indexEntry, bool) {
+	key, ok := l.key(values...)
+	if !ok {
+		return nil, false
+	}
+	e, ok := idx.byKey[key]
+	return e, ok
+}
+
+// permutation returns the catalog entry of the code with the given field
+// values
+func (idx *catalogIndex) permutation(l Layout, values ...uint32) (Permutation, bool) {
+	e, ok := idx.lookup(l, values...)
+	if !ok {
+		return Permutation{}, false
+	}
+	return e.perm, true
+}
+
+// permutations returns the entries in tree order, panicking if the tree
+// holds codes that cannot be encoded
+func (idx *catalogIndex) permutations() []Permutation {
+	if idx.err != nil {
+		panic(fmt.Sprintf("invalid catalog entry %v", idx.err))
+	}
+	perms := make([]Permutation, len(idx.entries))
+	for i, e := range idx.entries {
+		perms[i] = e.perm
+		perms[i].TableFields = slices.Clone(e.perm.TableFields)
+	}
+	return perms
+}
+
+// treeSet is an immutable copy of the tree variables, published by
+// ReplaceTrees. Codes, indexes, ParseName and Validate read the trees of
+// the current set without locking.
+type treeSet struct {
+	gen       uint64 // Incremented by every ReplaceTrees call
+	tiny      []TinyErrorInfo
+	simple    []SimpleClassInfo
+	simple511 []Simple5ClassInfo
+	app       []AppInfo
+}
+
+// newTreeSet copies the tree variables into a new set
+func newTreeSet(gen uint64) *treeSet {
+	return &treeSet{
+		gen:       gen,
+		tiny:      cloneEach(TinyCodeValues, func(e *TinyErrorInfo) { e.Localization = e.Localization.clone() }),
+		simple:    cloneEach(SimpleCodeTree, cloneSimpleClass),
+		simple511: cloneEach(Simple511CodeTree, cloneSimple511Class),
+		app:       cloneEach(CodeTree, cloneApp),
+	}
+}
+
+// treeMu serializes ReplaceTrees and the first publication of the tree
+// variables; reading the current set takes no lock
+var (
+	treeMu sync.Mutex
+	trees  atomic.Pointer[treeSet]
+)
+
+// currentTrees returns the published trees, publishing the tree variables
+// on first use
+func currentTrees() *treeSet {
+	if s := trees.Load(); s != nil {
+		return s
+	}
+	treeMu.Lock()
+	defer treeMu.Unlock()
+	if s := trees.Load(); s != nil {
+		return s
+	}
+	s := newTreeSet(0)
+	trees.Store(s)
+	return s
+}
+
+// ReplaceTrees calls fn, which assigns or modifies the code tree variables
+// such as CodeTree, and publishes a copy of the changed trees to the codes.
+// It is safe to call while other goroutines use codes, which see either the
+// previous or the changed trees. Assigning a tree variable without
+// ReplaceTrees only takes effect if no code was used before.
+func ReplaceTrees(fn func()) {
+	_ = ReplaceTreesChecked(fn, nil)
+}
+
+// ReplaceTreesChecked is like ReplaceTrees but calls check, if not nil,
+// once the changed trees are published. If check returns an error, the
+// tree variables are assigned their previous trees again, the previous
+// trees are published and the error is returned.
+func ReplaceTreesChecked(fn func(), check func() error) error {
+	treeMu.Lock()
+	defer treeMu.Unlock()
+
+	prev := trees.Load()
+	if prev == nil {
+		prev = newTreeSet(0)
+	}
+	tiny, simple, simple511, app := TinyCodeValues, SimpleCodeTree, Simple511CodeTree, CodeTree
+	fn()
+	trees.Store(newTreeSet(prev.gen + 1))
+
+	if check == nil {
+		return nil
+	}
+	if err := check(); err != nil {
+		TinyCodeValues, SimpleCodeTree, Simple511CodeTree, CodeTree = tiny, simple, simple511, app
+		trees.Store(prev)
+		return err
+	}
+	return nil
+}
+
+// lazyIndex builds the index of one tree of the current set on first use
+// and rebuilds it once ReplaceTrees published a new set
+type lazyIndex[T any] struct {
+	tree  func(s *treeSet) []T
+	build func(tree []T) *catalogIndex
+
+	mu    sync.Mutex
+	state atomic.Pointer[indexState]
+}
+
+// indexState is an index with the generation of the set it was built from
+type indexState struct {
+	gen   uint64
+	index *catalogIndex
+}
+
+func (l *lazyIndex[T]) get() *catalogIndex {
+	set := currentTrees()
+	if s := l.state.Load(); s != nil && s.gen == set.gen {
+		return s.index
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s := l.state.Load(); s != nil && s.gen == set.gen {
+		return s.index
+	}
+	s := &indexState{gen: set.gen, index: l.build(l.tree(set)).finish()}
+	l.state.Store(s)
+	return s.index
+}
+
+// cloneEach returns a copy of s with fn applied to every element of the
+// copy, for copying the slices and maps nested in tree entries
+func cloneEach[T any](s []T, fn func(*T)) []T {
+	s = slices.Clone(s)
+	for i := range s {
+		fn(&s[i])
+	}
+	return s
+}
+
+func (l Localization) clone() Localization {
+	return Localization{Descriptions: maps.Clone(l.Descriptions), Messages: maps.Clone(l.Messages)}
+}
+
+func cloneSimpleClass(c *SimpleClassInfo) {
+	c.ErrorTypes = cloneEach(c.ErrorTypes, func(e *SimpleErrorInfo) { e.Localization = e.Localization.clone() })
+}
+
+func cloneSimple511Class(c *Simple5ClassInfo) {
+	c.ErrorTypes = cloneEach(c.ErrorTypes, func(e *Simple11ErrorInfo) { e.Localization = e.Localization.clone() })
+}
+
+func cloneApp(a *AppInfo) {
+	a.Components = cloneEach(a.Components, func(c *ComponentInfo) {
+		c.SubComponents = cloneEach(c.SubComponents, func(s *SubComponentInfo) {
+			s.ErrorTypes = cloneEach(s.ErrorTypes, func(e *ErrorInfo) { e.Localization = e.Localization.clone() })
+		})
+	})
+}
@@ -0,0 +1,241 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// largeCodeTree returns a tree with 16*8*8*8 = 8192 error types
+func largeCodeTree() []AppInfo {
+	var tree []AppInfo
+	for a := 0; a < 16; a++ {
+		app := AppInfo{Value: AppCode(a), Name: fmt.Sprintf("app%d", a)}
+		for c := 0; c < 8; c++ {
+			comp := ComponentInfo{Value: ComponentCode(c), Name: fmt.Sprintf("comp%d", c)}
+			for s := 0; s < 8; s++ {
+				sub := SubComponentInfo{Value: SubComponentCode(s), Name: fmt.Sprintf("sub%d", s)}
+				for e := 0; e < 8; e++ {
+					sub.ErrorTypes = append(sub.ErrorTypes, ErrorInfo{
+						Value:       ErrorCode(e),
+						Name:        fmt.Sprintf("err%d", e),
+						Description: fmt.Sprintf("Error %d", e),
+					})
+				}
+				comp.SubComponents = append(comp.SubComponents, sub)
+			}
+			app.Components = append(app.Components, comp)
+		}
+		tree = append(tree, app)
+	}
+	return tree
+}
+
+// linearString is the nested walk String did before the index
+func linearString(e AppComponentErrorCode) string {
+	for _, app := range CodeTree {
+		if app.Value != e.App {
+			continue
+		}
+		for _, comp := range app.Components {
+			if comp.Value != e.Component {
+				continue
+			}
+			for _, sub := range comp.SubComponents {
+				if sub.Value != e.SubComponent {
+					continue
+				}
+				for _, errType := range sub.ErrorTypes {
+					if errType.Value == e.ErrType {
+						return app.Name + "." + comp.Name + "." + sub.Name + "." + errType.Name
+					}
+				}
+			}
+		}
+	}
+	return "invalid"
+}
+
+// setTrees changes the tree variables with ReplaceTrees and restores them
+// when the test ends
+func setTrees(tb testing.TB, fn func()) {
+	tb.Helper()
+	tiny, simple, simple511, app := TinyCodeValues, SimpleCodeTree, Simple511CodeTree, CodeTree
+	tb.Cleanup(func() {
+		ReplaceTrees(func() {
+			TinyCodeValues, SimpleCodeTree, Simple511CodeTree, CodeTree = tiny, simple, simple511, app
+		})
+	})
+	ReplaceTrees(fn)
+}
+
+func useLargeCodeTree(tb testing.TB) {
+	setTrees(tb, func() { CodeTree = largeCodeTree() })
+}
+
+func TestIndexMatchesTree(t *testing.T) {
+	useLargeCodeTree(t)
+
+	for _, code := range []AppComponentErrorCode{
+		{App: 0, Component: 0, SubComponent: 0, ErrType: 0},
+		{App: 7, Component: 3, SubComponent: 5, ErrType: 2},
+		{App: 15, Component: 7, SubComponent: 7, ErrType: 7},
+		{App: 15, Component: 7, SubComponent: 7, ErrType: 8},
+	} {
+		if got, want := code.String(), linearString(code); got != want {
+			t.Errorf("%+v.String() = %q; want %q", code, got, want)
+		}
+	}
+
+	p, ok := Lookup(AppComponentErrorCode{App: 7, Component: 3, SubComponent: 5, ErrType: 2})
+	if !ok || p.Fields["Description"] != "Error 2" || p.TableFields[0] != p.Code {
+		t.Errorf("Lookup() = %+v, %v", p, ok)
+	}
+	if n := len(AppComponentErrorCode{}.GetPermutations()); n != 8192 {
+		t.Errorf("GetPermutations() returned %d entries; want 8192", n)
+	}
+}
+
+func TestIndexRebuiltOnReplaceTrees(t *testing.T) {
+	setTrees(t, func() {
+		SimpleCodeTree = []SimpleClassInfo{
+			{Value: 1, Name: "api", ErrorTypes: []SimpleErrorInfo{{Value: 1, Name: "error"}}},
+		}
+	})
+
+	code := SimpleCode{Class: 1, ErrType: 1}
+	if got := code.String(); got != "api.error" {
+		t.Fatalf("String() = %q; want %q", got, "api.error")
+	}
+	ReplaceTrees(func() {
+		SimpleCodeTree[0].ErrorTypes[0].Name = "failure"
+	})
+	if got := code.String(); got != "api.failure" {
+		t.Errorf("String() after changing the tree in place = %q; want %q", got, "api.failure")
+	}
+
+	// Without ReplaceTrees the published copy is unchanged
+	SimpleCodeTree[0].ErrorTypes[0].Name = "changed"
+	if got := code.String(); got != "api.failure" {
+		t.Errorf("String() after changing the variable = %q; want %q", got, "api.failure")
+	}
+}
+
+func TestReplaceTreesChecked(t *testing.T) {
+	setTrees(t, func() {
+		SimpleCodeTree = []SimpleClassInfo{
+			{Value: 1, Name: "api", ErrorTypes: []SimpleErrorInfo{{Value: 1, Name: "error"}}},
+		}
+	})
+	code := SimpleCode{Class: 1, ErrType: 1}
+
+	failed := stderrors.New("check failed")
+	err := ReplaceTreesChecked(func() {
+		SimpleCodeTree = []SimpleClassInfo{
+			{Value: 1, Name: "renamed", ErrorTypes: []SimpleErrorInfo{{Value: 1, Name: "error"}}},
+		}
+	}, func() error {
+		if got := code.String(); got != "renamed.error" {
+			t.Errorf("String() during check = %q; want %q", got, "renamed.error")
+		}
+		return failed
+	})
+	if err != failed {
+		t.Errorf("ReplaceTreesChecked() = %v; want %v", err, failed)
+	}
+	if got := code.String(); got != "api.error" {
+		t.Errorf("String() after failed check = %q; want %q", got, "api.error")
+	}
+	if SimpleCodeTree[0].Name != "api" {
+		t.Errorf("SimpleCodeTree not restored: %+v", SimpleCodeTree)
+	}
+}
+
+func TestReplaceTreesConcurrentUse(t *testing.T) {
+	trees := [][]SimpleClassInfo{
+		{{Value: 1, Name: "a", ErrorTypes: []SimpleErrorInfo{{Value: 1, Name: "error"}}}},
+		{{Value: 1, Name: "b", ErrorTypes: []SimpleErrorInfo{{Value: 1, Name: "error"}}}},
+	}
+	setTrees(t, func() { SimpleCodeTree = trees[0] })
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			ReplaceTrees(func() { SimpleCodeTree = trees[i%2] })
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code := SimpleCode{Class: 1, ErrType: 1}
+			for j := 0; j < 100; j++ {
+				if got := code.String(); got != "a.error" && got != "b.error" {
+					t.Errorf("String() = %q; want a.error or b.error", got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIndexConcurrentUse(t *testing.T) {
+	useLargeCodeTree(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			code := AppComponentErrorCode{App: AppCode(i), Component: 1, SubComponent: 2, ErrType: 3}
+			want := fmt.Sprintf("app%d.comp1.sub2.err3", i)
+			if got := code.String(); got != want {
+				t.Errorf("String() = %q; want %q", got, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkAppComponentString(b *testing.B) {
+	useLargeCodeTree(b)
+	// The last entry is the worst case for the linear walk
+	code := AppComponentErrorCode{App: 15, Component: 7, SubComponent: 7, ErrType: 7}
+	_ = code.String()
+
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = code.String()
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = linearString(code)
+		}
+	})
+}
+
+func BenchmarkLookup(b *testing.B) {
+	useLargeCodeTree(b)
+	code := AppComponentErrorCode{App: 15, Component: 7, SubComponent: 7, ErrType: 7}
+	encoded := code.Encode()
+	Lookup(code)
+
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Lookup(code)
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, p := range code.GetPermutations() {
+				if p.Code == encoded {
+					break
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,302 @@
+package errors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+)
+
+// LayoutField is a named run of bits in the packed data of a code
+type LayoutField struct {
+	Name        string
+	Bits        int
+	Description string
+}
+
+// Key returns the key of the field in logs, metrics, JSON and other
+// output, e.g. "sub_component" for SubComponent
+func (f LayoutField) Key() string {
+	return naming.FieldKey(f.Name)
+}
+
+// Layout declares how a format packs its fields into base36 data. Encoding,
+// decoding, field info, capacity numbers and the bit diagram used in the
+// docs are all derived from it.
+type Layout struct {
+	Type   CodeType
+	Width  int           // Number of base36 characters used for the data
+	Fields []LayoutField // Most significant field first
+}
+
+// Bits returns the total number of bits of all fields
+func (l Layout) Bits() int {
+	bits := 0
+	for _, f := range l.Fields {
+		bits += f.Bits
+	}
+	return bits
+}
+
+// Length returns the length of an encoded code: prefix, type and data
+func (l Layout) Length() int {
+	return 2 + l.Width
+}
+
+// Capacity returns the number of distinct packed values, limited by both
+// the number of bits and the number of base36 characters
+func (l Layout) Capacity() uint64 {
+	return min(uint64(1)<<l.Bits(), uint64(math.Pow(36, float64(l.Width))))
+}
+
+// MaxValue returns the largest value the i-th field can hold
+func (l Layout) MaxValue(i int) uint32 {
+	maxValue := uint64(1)<<l.Fields[i].Bits - 1
+	if i == 0 {
+		// The most significant field is also limited by the data width
+		maxValue = min(maxValue, (l.Capacity()-1)>>l.shift(0))
+	}
+	return uint32(maxValue)
+}
+
+// shift returns the bit offset of the i-th field
+func (l Layout) shift(i int) int {
+	shift := 0
+	for _, f := range l.Fields[i+1:] {
+		shift += f.Bits
+	}
+	return shift
+}
+
+// Pack packs field values, given in field order, into a single number
+func (l Layout) Pack(values ...uint32) (uint32, error) {
+	if len(values) != len(l.Fields) {
+		return 0, fmt.Errorf("layout has %d fields, got %d values", len(l.Fields), len(values))
+	}
+
+	var packed uint64
+	for i, f := range l.Fields {
+		if values[i] > l.MaxValue(i) {
+			return 0, fmt.Errorf("%w: %s value %d exceeds maximum of %d", ErrOverflow, f.Name, values[i], l.MaxValue(i))
+		}
+		packed = packed<<f.Bits | uint64(values[i])
+	}
+	if packed >= l.Capacity() {
+		return 0, fmt.Errorf("%w: packed value %d exceeds maximum of %d", ErrOverflow, packed, l.Capacity()-1)
+	}
+	return uint32(packed), nil
+}
+
+// key packs field values like Pack but without the capacity check, for
+// index lookups. It reports false if a value does not fit into its field.
+func (l Layout) key(values ...uint32) (uint32, bool) {
+	var packed uint32
+	for i, f := range l.Fields {
+		if uint64(values[i]) >= 1<<f.Bits {
+			return 0, false
+		}
+		packed = packed<<f.Bits | values[i]
+	}
+	return packed, true
+}
+
+// Unpack splits a packed number into field values in field order
+func (l Layout) Unpack(packed uint32) []uint32 {
+	values := make([]uint32, len(l.Fields))
+	for i := len(l.Fields) - 1; i >= 0; i-- {
+		bits := l.Fields[i].Bits
+		values[i] = packed & (1<<bits - 1)
+		packed >>= bits
+	}
+	return values
+}
+
+// Encode packs the field values and encodes them as E<type><data>
+func (l Layout) Encode(values ...uint32) (string, error) {
+	packed, err := l.Pack(values...)
+	if err != nil {
+		return "", err
+	}
+
+	// Convert type and data to base36
+	typeStr, err := toBase36(uint32(l.Type), 1)
+	if err != nil {
+		return "", err
+	}
+	dataStr, err := toBase36(packed, l.Width)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("E%s%s", typeStr, dataStr), nil
+}
+
+// EncodeMasked is like Encode but never fails: each value is masked to the
+// bits of its field and data beyond the base36 width wraps around, so out
+// of range values alias other codes. It backs the Encode methods of the
+// code types; EncodeChecked is the strict path.
+func (l Layout) EncodeMasked(values ...uint32) string {
+	var packed uint64
+	for i, f := range l.Fields {
+		var v uint32
+		if i < len(values) {
+			v = values[i]
+		}
+		packed = packed<<f.Bits | uint64(v)&(1<<f.Bits-1)
+	}
+
+	// Both fit: the type is below 36 and the data below the capacity
+	typeStr, _ := toBase36(uint32(l.Type)%36, 1)
+	dataStr, _ := toBase36(uint32(packed%l.Capacity()), l.Width)
+	return "E" + typeStr + dataStr
+}
+
+// Decode validates a code of this layout and returns its field values
+func (l Layout) Decode(code string) ([]uint32, error) {
+	packed, err := parseCode(code, l.Type, l.Length())
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate packed data doesn't exceed our bit limits
+	if uint64(packed) >= l.Capacity() {
+		return nil, &DecodeError{Code: code, Pos: -1, Err: ErrOverflow}
+	}
+	return l.Unpack(packed), nil
+}
+
+// FieldInfo returns the field descriptions, with the known values of each
+// field given in field order
+func (l Layout) FieldInfo(values ...string) []FieldInfo {
+	info := make([]FieldInfo, len(l.Fields))
+	for i, f := range l.Fields {
+		info[i] = FieldInfo{
+			Name:        f.Name,
+			Bits:        f.Bits,
+			Description: fmt.Sprintf("%s (0-%d)", f.Description, l.MaxValue(i)),
+		}
+		if i < len(values) {
+			info[i].Values = values[i]
+		}
+	}
+	return info
+}
+
+// Diagram returns the bit layout grouped into bytes, followed by a legend
+// using the first letter of each field name
+func (l Layout) Diagram() string {
+	var bits strings.Builder
+	for _, f := range l.Fields {
+		bits.WriteString(strings.Repeat(l.letter(f), f.Bits))
+	}
+
+	// Group bits into bytes, aligned to the least significant bit
+	all := bits.String()
+	var groups []string
+	for end := len(all); end > 0; end -= 8 {
+		groups = append([]string{all[max(0, end-8):end]}, groups...)
+	}
+
+	var b strings.Builder
+	b.WriteString("[" + strings.Join(groups, "][") + "]")
+	for _, f := range l.Fields {
+		fmt.Fprintf(&b, "\n%s: %s bits (%d)", l.letter(f), f.Name, f.Bits)
+	}
+	return b.String()
+}
+
+func (l Layout) letter(f LayoutField) string {
+	return strings.ToUpper(f.Name[:1])
+}
+
+// Describe returns the markdown documentation of the layout: fields,
+// capacity, encoding and bit diagram
+func (l Layout) Describe() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Each error code is composed of %d bits of data encoded as follows:\n", l.Bits())
+	for i, f := range l.Fields {
+		fmt.Fprintf(&b, "- %s (%d bits): %s (allows up to %s values)\n",
+			f.Name, f.Bits, f.Description, formatCount(uint64(l.MaxValue(i))+1))
+	}
+
+	b.WriteString("\nThe format provides:\n")
+	var counts []string
+	for i, f := range l.Fields {
+		count := formatCount(uint64(l.MaxValue(i)) + 1)
+		counts = append(counts, count)
+		if i == 0 {
+			fmt.Fprintf(&b, "- Up to %s different %s values\n", count, f.Name)
+		} else {
+			fmt.Fprintf(&b, "- Up to %s different %s values per %s\n", count, f.Name, l.Fields[i-1].Name)
+		}
+	}
+	fmt.Fprintf(&b, "- Total of %s possible unique error codes", formatCount(l.Capacity()))
+	if len(counts) > 1 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(counts, " * "))
+	}
+
+	fmt.Fprintf(&b, `
+
+The code is encoded as E<type><data> where:
+- E: Fixed prefix
+- type: 1 base-36 character encoding the format type (%s)
+- data: %d base-36 characters encoding the packed %d bits
+
+Bit layout before encoding:
+`+"```"+`
+%s
+`+"```", base36Chars[l.Type%36:l.Type%36+1], l.Width, l.Bits(), l.Diagram())
+
+	return b.String()
+}
+
+// validate checks that the layout fits into a single packed uint32
+func (l Layout) validate() error {
+	if len(l.Fields) == 0 {
+		return fmt.Errorf("layout has no fields")
+	}
+	if l.Bits() > 32 {
+		return fmt.Errorf("layout has %d bits, maximum is 32", l.Bits())
+	}
+	if l.Width < 1 || l.Width > 6 {
+		return fmt.Errorf("layout width %d out of range 1-6", l.Width)
+	}
+	for _, f := range l.Fields {
+		if f.Name == "" || f.Bits < 1 {
+			return fmt.Errorf("layout field %q must have a name and at least one bit", f.Name)
+		}
+	}
+	return nil
+}
+
+// formatCount formats a number with thousands separators
+func formatCount(n uint64) string {
+	s := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// valueList formats names and values as "name(value), ..." skipping
+// duplicates
+func valueList[T any](items []T, entry func(T) (string, uint32)) string {
+	seen := map[string]bool{}
+	var parts []string
+	for _, item := range items {
+		name, value := entry(item)
+		part := fmt.Sprintf("%s(%d)", name, value)
+		if seen[part] {
+			continue
+		}
+		seen[part] = true
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
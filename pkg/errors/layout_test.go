@@ -0,0 +1,91 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestLayoutEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		values []uint32
+		code   string
+	}{
+		{"tiny zero", tinyLayout, []uint32{0}, "E000"},
+		{"tiny max", tinyLayout, []uint32{1295}, "E0ZZ"},
+		{"simple", simpleLayout, []uint32{1, 2}, "E10076"},
+		{"simple511 max", simple511Layout, []uint32{31, 2047}, "E31EKF"},
+		{"app component max", appComponentLayout, []uint32{15, 63, 63, 255}, "EA9ZLDR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := tt.layout.Encode(tt.values...)
+			if err != nil {
+				t.Fatalf("Encode(%v) returned error: %v", tt.values, err)
+			}
+			if code != tt.code {
+				t.Errorf("Encode(%v) = %s; want %s", tt.values, code, tt.code)
+			}
+
+			values, err := tt.layout.Decode(code)
+			if err != nil {
+				t.Fatalf("Decode(%s) returned error: %v", code, err)
+			}
+			for i := range values {
+				if values[i] != tt.values[i] {
+					t.Errorf("Decode(%s) = %v; want %v", code, values, tt.values)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLayoutOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		values []uint32
+	}{
+		{"tiny beyond width", tinyLayout, []uint32{1296}},
+		{"simple511 class", simple511Layout, []uint32{32, 0}},
+		{"app", appComponentLayout, []uint32{16, 0, 0, 0}},
+		{"sub-component", appComponentLayout, []uint32{1, 1, 64, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.layout.Pack(tt.values...); !stderrors.Is(err, ErrOverflow) {
+				t.Errorf("Pack(%v) error = %v; want %v", tt.values, err, ErrOverflow)
+			}
+		})
+	}
+}
+
+func TestLayoutDerivedInfo(t *testing.T) {
+	if got := appComponentLayout.Capacity(); got != 1<<24 {
+		t.Errorf("Capacity() = %d; want %d", got, 1<<24)
+	}
+	if got := tinyLayout.MaxValue(0); got != 1295 {
+		t.Errorf("tiny MaxValue(0) = %d; want 1295", got)
+	}
+	if got := appComponentLayout.Fields[2].Key(); got != "sub_component" {
+		t.Errorf("Key() = %s; want sub_component", got)
+	}
+
+	wantDiagram := "[AAAACCCC][CCSSSSSS][EEEEEEEE]\n" +
+		"A: App bits (4)\n" +
+		"C: Component bits (6)\n" +
+		"S: SubComponent bits (6)\n" +
+		"E: ErrorType bits (8)"
+	if got := appComponentLayout.Diagram(); got != wantDiagram {
+		t.Errorf("Diagram() = %q; want %q", got, wantDiagram)
+	}
+
+	info := simple511Layout.FieldInfo("http(1)", "bad_request(1)")
+	if info[1].Bits != 11 || info[1].Description != "Identifies the specific error (0-2047)" || info[1].Values != "bad_request(1)" {
+		t.Errorf("FieldInfo()[1] = %+v", info[1])
+	}
+}
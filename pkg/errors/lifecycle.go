@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Status is the lifecycle state of a catalog entry
+type Status string
+
+const (
+	StatusActive     Status = "active"
+	StatusDeprecated Status = "deprecated"
+	StatusRetired    Status = "retired"
+)
+
+// severity orders statuses so that the most restrictive one wins when a
+// code inherits the lifecycle of its ancestors
+func (s Status) severity() int {
+	switch s {
+	case StatusDeprecated:
+		return 1
+	case StatusRetired:
+		return 2
+	}
+	return 0
+}
+
+// Valid reports whether s is a known status. The empty status means active.
+func (s Status) Valid() bool {
+	switch s {
+	case "", StatusActive, StatusDeprecated, StatusRetired:
+		return true
+	}
+	return false
+}
+
+// Lifecycle holds the deprecation metadata of a catalog entry. It is
+// embedded in the info structs of every tree level.
+type Lifecycle struct {
+	Status          Status // Empty means active
+	DeprecatedSince string // Version in which the entry was deprecated
+	Replacement     string // Encoded code to use instead
+}
+
+// State returns the status, treating the empty status as active
+func (l Lifecycle) State() Status {
+	if l.Status == "" {
+		return StatusActive
+	}
+	return l.Status
+}
+
+// Deprecated reports whether the entry is deprecated or retired
+func (l Lifecycle) Deprecated() bool {
+	return l.State() != StatusActive
+}
+
+func (l Lifecycle) String() string {
+	s := string(l.State())
+	if l.DeprecatedSince != "" {
+		s += " since " + l.DeprecatedSince
+	}
+	if l.Replacement != "" {
+		s += ", use " + l.Replacement
+	}
+	return s
+}
+
+// inheritLifecycle returns the lifecycle of a code from the lifecycles of
+// its tree nodes, given from leaf to root. The most restrictive status
+// wins, the nearest node providing it supplies the metadata.
+func inheritLifecycle(levels ...Lifecycle) Lifecycle {
+	var result Lifecycle
+	for _, l := range levels {
+		if l.State().severity() > result.State().severity() {
+			result = l
+		}
+	}
+	return result
+}
+
+var deprecationHandler atomic.Pointer[func(*Error, Lifecycle)]
+
+// SetDeprecationHandler registers a function called by New, Newf and Wrap
+// when the code of the created error is deprecated or retired. A nil
+// handler disables the check, which is the default.
+func SetDeprecationHandler(h func(err *Error, lifecycle Lifecycle)) {
+	if h == nil {
+		deprecationHandler.Store(nil)
+		return
+	}
+	deprecationHandler.Store(&h)
+}
+
+// LogDeprecated returns a deprecation handler logging a warning to logger
+func LogDeprecated(logger *slog.Logger) func(*Error, Lifecycle) {
+	return func(err *Error, lifecycle Lifecycle) {
+		logger.Warn("deprecated error code raised",
+			"code", codeMessage(err.Code),
+			"lifecycle", lifecycle.String(),
+		)
+	}
+}
+
+// checkDeprecated calls the deprecation handler if one is set and the code
+// of err is deprecated
+func checkDeprecated(err *Error) {
+	h := deprecationHandler.Load()
+	if h == nil || err.Code == nil {
+		return
+	}
+	if p, ok := Lookup(err.Code); ok && p.Lifecycle.Deprecated() {
+		(*h)(err, p.Lifecycle)
+	}
+}
+
+// checkLifecycle reports problems with the lifecycle metadata of an entry
+func checkLifecycle(l Lifecycle) []string {
+	var msgs []string
+	if !l.Status.Valid() {
+		msgs = append(msgs, fmt.Sprintf("unknown status %q", l.Status))
+	}
+	if l.DeprecatedSince != "" && !l.Deprecated() {
+		msgs = append(msgs, "deprecated since set on an active entry")
+	}
+	if l.Replacement != "" {
+		code, err := Decode(l.Replacement)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("invalid replacement: %v", err))
+		} else if p, ok := Lookup(code); !ok {
+			msgs = append(msgs, fmt.Sprintf("replacement %s is not in the catalog", l.Replacement))
+		} else if p.Lifecycle.Deprecated() {
+			msgs = append(msgs, fmt.Sprintf("replacement %s is %s itself", l.Replacement, p.Lifecycle.State()))
+		}
+	}
+	return msgs
+}
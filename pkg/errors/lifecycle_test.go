@@ -0,0 +1,87 @@
+package errors
+
+import "testing"
+
+func TestInheritLifecycle(t *testing.T) {
+	deprecated := Lifecycle{Status: StatusDeprecated, DeprecatedSince: "v1.2.0"}
+	retired := Lifecycle{Status: StatusRetired, DeprecatedSince: "v2.0.0"}
+
+	tests := []struct {
+		name   string
+		levels []Lifecycle
+		want   Lifecycle
+	}{
+		{"all active", []Lifecycle{{}, {}}, Lifecycle{}},
+		{"deprecated parent", []Lifecycle{{}, deprecated}, deprecated},
+		{"leaf wins on equal status", []Lifecycle{{Status: StatusDeprecated, Replacement: "E001"}, deprecated}, Lifecycle{Status: StatusDeprecated, Replacement: "E001"}},
+		{"retired beats deprecated", []Lifecycle{deprecated, retired}, retired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inheritLifecycle(tt.levels...); got != tt.want {
+				t.Errorf("inheritLifecycle() = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecationHandler(t *testing.T) {
+	setTrees(t, func() {
+		SimpleCodeTree = []SimpleClassInfo{
+			{
+				Value: 1,
+				Name:  "api",
+				ErrorTypes: []SimpleErrorInfo{
+					{Value: 0, Name: "unknown"},
+					{Value: 1, Name: "validation_error"},
+				},
+				Lifecycle: Lifecycle{Status: StatusDeprecated, DeprecatedSince: "v1.2.0", Replacement: "E000"},
+			},
+		}
+	})
+
+	var raised []Lifecycle
+	SetDeprecationHandler(func(err *Error, l Lifecycle) {
+		raised = append(raised, l)
+	})
+	defer SetDeprecationHandler(nil)
+
+	New(SimpleCode{Class: 1, ErrType: 1}, "deprecated")
+	New(TinyCode{ErrType: 1}, "active")
+
+	if len(raised) != 1 || raised[0].String() != "deprecated since v1.2.0, use E000" {
+		t.Errorf("deprecation handler called with %v; want one deprecated lifecycle", raised)
+	}
+	if problems := validateSimple(); len(problems) != 0 {
+		t.Errorf("validateSimple() = %v; want no problems", problems)
+	}
+}
+
+func TestValidateLifecycle(t *testing.T) {
+	setTrees(t, func() {
+		TinyCodeValues = []TinyErrorInfo{
+			{Value: 0, Name: "unknown"},
+			{Value: 1, Name: "old", Lifecycle: Lifecycle{Status: "obsolete"}},
+			{Value: 2, Name: "early", Lifecycle: Lifecycle{DeprecatedSince: "v1.0.0"}},
+			{Value: 3, Name: "chained", Lifecycle: Lifecycle{Status: StatusDeprecated, Replacement: "E004"}},
+			{Value: 4, Name: "gone", Lifecycle: Lifecycle{Status: StatusRetired, Replacement: "E0ZZ"}},
+		}
+	})
+
+	want := []string{
+		`tiny: old: unknown status "obsolete"`,
+		`tiny: early: deprecated since set on an active entry`,
+		`tiny: chained: replacement E004 is retired itself`,
+		`tiny: gone: replacement E0ZZ is not in the catalog`,
+	}
+	problems := validateTiny()
+	if len(problems) != len(want) {
+		t.Fatalf("validateTiny() = %v; want %d problems", problems, len(want))
+	}
+	for i := range want {
+		if problems[i].String() != want[i] {
+			t.Errorf("problem %d = %s; want %s", i, problems[i], want[i])
+		}
+	}
+}
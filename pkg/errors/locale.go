@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the locale of the Description fields and the last step
+// of every fallback chain
+const DefaultLocale = "en"
+
+// Localization holds the translated texts of a catalog entry by locale,
+// e.g. "de" or "de-AT". It is embedded in the info structs of the error
+// type level of every tree.
+type Localization struct {
+	Descriptions map[string]string // Developer-facing description, English in Description
+	Messages     map[string]string // End-user message
+}
+
+// LocaleFallbacks returns the locales tried for locale, from the most to the
+// least specific, ending with DefaultLocale: "de-AT" gives de-AT, de, en.
+// Underscores are accepted as separators.
+func LocaleFallbacks(locale string) []string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+
+	var chain []string
+	for locale != "" {
+		chain = append(chain, locale)
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	if len(chain) == 0 || !strings.EqualFold(chain[len(chain)-1], DefaultLocale) {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+// localized returns the text for the first locale of the fallback chain
+// present in texts. Locales are compared case-insensitively.
+func localized(texts map[string]string, locale string) (string, bool) {
+	if len(texts) == 0 {
+		return "", false
+	}
+	for _, l := range LocaleFallbacks(locale) {
+		if s, ok := texts[l]; ok {
+			return s, true
+		}
+		for k, s := range texts {
+			if strings.EqualFold(k, l) {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Description returns the description of the code in the given locale,
+// falling back to the English Description
+func (p Permutation) Description(locale string) string {
+	if s, ok := localized(p.Localization.Descriptions, locale); ok {
+		return s
+	}
+	return p.Fields["Description"]
+}
+
+// Message returns the end-user message of the code in the given locale,
+// falling back to the localized description if no message is defined
+func (p Permutation) Message(locale string) string {
+	if s, ok := localized(p.Localization.Messages, locale); ok {
+		return s
+	}
+	return p.Description(locale)
+}
+
+// Message returns the end-user message of a code in the given locale, or
+// an empty string if the code is not in the catalog
+func Message(code ErrorType, locale string) string {
+	p, ok := Lookup(code)
+	if !ok {
+		return ""
+	}
+	return p.Message(locale)
+}
+
+// Locales returns the sorted locales other than DefaultLocale that have
+// texts in the catalog of any registered format
+func Locales() []string {
+	seen := map[string]bool{}
+	for _, f := range Formats() {
+		for _, p := range f.Prototype.GetPermutations() {
+			for l := range p.Localization.Descriptions {
+				seen[l] = true
+			}
+			for l := range p.Localization.Messages {
+				seen[l] = true
+			}
+		}
+	}
+
+	var locales []string
+	for l := range seen {
+		if !strings.EqualFold(l, DefaultLocale) {
+			locales = append(locales, l)
+		}
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// checkLocalization reports malformed locales and empty texts
+func checkLocalization(l Localization) []string {
+	var msgs []string
+	for _, texts := range []struct {
+		kind string
+		m    map[string]string
+	}{{"description", l.Descriptions}, {"message", l.Messages}} {
+		locales := make([]string, 0, len(texts.m))
+		for locale := range texts.m {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+
+		seen := map[string]string{}
+		for _, locale := range locales {
+			switch folded := strings.ToLower(locale); {
+			case !validLocale(locale):
+				msgs = append(msgs, fmt.Sprintf("invalid %s locale %q", texts.kind, locale))
+			case seen[folded] != "":
+				msgs = append(msgs, fmt.Sprintf("duplicate %s locale %q, also given as %q", texts.kind, locale, seen[folded]))
+			case texts.m[locale] == "":
+				msgs = append(msgs, fmt.Sprintf("empty %s %s", locale, texts.kind))
+			default:
+				seen[folded] = locale
+			}
+		}
+	}
+	return msgs
+}
+
+// validLocale reports whether locale looks like a BCP 47 tag: a language of
+// two or three letters followed by alphanumeric subtags
+func validLocale(locale string) bool {
+	parts := strings.Split(locale, "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 {
+		return false
+	}
+	for i, part := range parts {
+		if part == "" || len(part) > 8 {
+			return false
+		}
+		for _, r := range part {
+			isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
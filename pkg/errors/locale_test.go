@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocaleFallbacks(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"de-AT", []string{"de-AT", "de", "en"}},
+		{"de_AT", []string{"de-AT", "de", "en"}},
+		{"zh-Hant-TW", []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{"en-GB", []string{"en-GB", "en"}},
+		{"EN", []string{"EN"}},
+		{"", []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := LocaleFallbacks(tt.locale); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LocaleFallbacks(%q) = %v; want %v", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+// useLocalizedTree installs a simple tree with a translated description
+// and end-user messages on api.validation_error
+func useLocalizedTree(t *testing.T) {
+	setTrees(t, func() {
+		SimpleCodeTree = []SimpleClassInfo{
+			{Value: 1, Name: "api", ErrorTypes: []SimpleErrorInfo{
+				{Value: 1, Name: "validation_error", Description: "API validation error", Localization: Localization{
+					Descriptions: map[string]string{"de": "API-Validierungsfehler"},
+					Messages: map[string]string{
+						"en": "Some of the entered data is invalid.",
+						"de": "Einige der eingegebenen Daten sind ungültig.",
+					},
+				}},
+			}},
+			{Value: 2, Name: "jobs", ErrorTypes: []SimpleErrorInfo{
+				{Value: 2, Name: "timeout", Description: "Job execution timeout"},
+			}},
+		}
+	})
+}
+
+func TestMessage(t *testing.T) {
+	useLocalizedTree(t)
+	validation := SimpleCode{Class: 1, ErrType: 1}
+	timeout := SimpleCode{Class: 2, ErrType: 2}
+
+	tests := []struct {
+		name   string
+		code   ErrorType
+		locale string
+		want   string
+	}{
+		{"exact locale", validation, "de", "Einige der eingegebenen Daten sind ungültig."},
+		{"region falls back to language", validation, "de-AT", "Einige der eingegebenen Daten sind ungültig."},
+		{"case insensitive", validation, "DE", "Einige der eingegebenen Daten sind ungültig."},
+		{"unknown locale falls back to English", validation, "fr", "Some of the entered data is invalid."},
+		{"no message falls back to description", timeout, "de", "Job execution timeout"},
+		{"code not in catalog", SimpleCode{Class: 9, ErrType: 9}, "en", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.code, tt.locale); got != tt.want {
+				t.Errorf("Message(%v, %q) = %q; want %q", tt.code, tt.locale, got, tt.want)
+			}
+		})
+	}
+
+	p, _ := Lookup(validation)
+	if got := p.Description("de-CH"); got != "API-Validierungsfehler" {
+		t.Errorf("Description(de-CH) = %q", got)
+	}
+	if got := p.Description("fr"); got != "API validation error" {
+		t.Errorf("Description(fr) = %q", got)
+	}
+}
+
+func TestLocales(t *testing.T) {
+	if got := Locales(); len(got) != 0 {
+		t.Errorf("Locales() of the built-in catalog = %v; want none", got)
+	}
+	useLocalizedTree(t)
+	if got := Locales(); !reflect.DeepEqual(got, []string{"de"}) {
+		t.Errorf("Locales() = %v; want [de]", got)
+	}
+}
+
+func TestValidateLocalization(t *testing.T) {
+	setTrees(t, func() {
+		TinyCodeValues = []TinyErrorInfo{
+			{Value: 0, Name: "unknown"},
+			{Value: 1, Name: "ok", Localization: Localization{
+				Descriptions: map[string]string{"de": "Gut", "zh-Hant-TW": "好"},
+			}},
+			{Value: 2, Name: "bad", Localization: Localization{
+				Descriptions: map[string]string{"german": "Schlecht", "de": ""},
+				Messages:     map[string]string{"de-AT": "Schlecht", "de-at": "Schlecht"},
+			}},
+		}
+	})
+
+	want := []string{
+		`tiny: bad: empty de description`,
+		`tiny: bad: invalid description locale "german"`,
+		`tiny: bad: duplicate message locale "de-at", also given as "de-AT"`,
+	}
+	problems := validateTiny()
+	if len(problems) != len(want) {
+		t.Fatalf("validateTiny() = %v; want %d problems", problems, len(want))
+	}
+	for i := range want {
+		if problems[i].String() != want[i] {
+			t.Errorf("problem %d = %s; want %s", i, problems[i], want[i])
+		}
+	}
+}
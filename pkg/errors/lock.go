@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// LockEntry records a code that has been allocated at some point
+type LockEntry struct {
+	Code        string `json:"code"`
+	Format      string `json:"format"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	FirstSeen   string `json:"first_seen"`
+	Retired     bool   `json:"retired,omitempty"`
+}
+
+// Lock is the committed list of every code ever allocated. A code keeps the
+// path it was allocated for: changing its meaning takes retiring the code and
+// allocating a new one.
+type Lock struct {
+	Entries []LockEntry `json:"entries"`
+}
+
+// ReadLock reads a lock written by Lock.Write
+func ReadLock(r io.Reader) (*Lock, error) {
+	var l Lock
+	if err := json.NewDecoder(r).Decode(&l); err != nil {
+		return nil, fmt.Errorf("reading lock: %w", err)
+	}
+	return &l, nil
+}
+
+// LoadLock reads the lock file at path
+func LoadLock(path string) (*Lock, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadLock(f)
+}
+
+// Write writes the lock as indented JSON
+func (l *Lock) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
+
+// Lookup returns the lock entry of a code
+func (l *Lock) Lookup(code string) (LockEntry, bool) {
+	for _, e := range l.Entries {
+		if e.Code == code {
+			return e, true
+		}
+	}
+	return LockEntry{}, false
+}
+
+// Retired returns the retired entries of the lock
+func (l *Lock) Retired() []LockEntry {
+	var retired []LockEntry
+	for _, e := range l.Entries {
+		if e.Retired {
+			retired = append(retired, e)
+		}
+	}
+	return retired
+}
+
+// Check reports every code of the snapshot whose path differs from the
+// locked one: retired codes reused for a different path and active codes
+// that were renamed or moved
+func (l *Lock) Check(s Snapshot) []Problem {
+	var problems []Problem
+	for _, e := range s {
+		locked, ok := l.Lookup(e.Code)
+		if !ok || locked.Path == e.Path {
+			continue
+		}
+		msg := fmt.Sprintf("changes path of code %s from %q (first seen %s); retire it and allocate a new code", e.Code, locked.Path, locked.FirstSeen)
+		if locked.Retired {
+			msg = fmt.Sprintf("reuses retired code %s of %q (first seen %s)", e.Code, locked.Path, locked.FirstSeen)
+		}
+		problems = append(problems, Problem{
+			Format:  e.Format,
+			Path:    e.Path,
+			Message: msg,
+		})
+	}
+	return problems
+}
+
+// Update records the codes of the snapshot: new codes are added as first
+// seen in version, codes missing from the snapshot or retired in the
+// catalog are marked retired and restored codes are reactivated. Entries of
+// codes whose path changed are left as they are, as Check reports them and
+// must pass before updating.
+func (l *Lock) Update(s Snapshot, version string) {
+	current := map[string]SnapshotEntry{}
+	for _, e := range s {
+		current[e.Code] = e
+	}
+
+	locked := map[string]bool{}
+	for i := range l.Entries {
+		e := &l.Entries[i]
+		locked[e.Code] = true
+		c, ok := current[e.Code]
+		switch {
+		case !ok:
+			e.Retired = true
+		case c.Path == e.Path:
+			e.Retired = c.Retired
+			e.Description = c.Description
+		}
+	}
+
+	for _, e := range s {
+		if locked[e.Code] {
+			continue
+		}
+		l.Entries = append(l.Entries, LockEntry{
+			Code:        e.Code,
+			Format:      e.Format,
+			Path:        e.Path,
+			Description: e.Description,
+			FirstSeen:   version,
+			Retired:     e.Retired,
+		})
+	}
+	sort.Slice(l.Entries, func(i, j int) bool {
+		return l.Entries[i].Code < l.Entries[j].Code
+	})
+}
@@ -0,0 +1,86 @@
+package errors
+
+import "testing"
+
+func TestLockUpdate(t *testing.T) {
+	lock := &Lock{}
+	lock.Update(Snapshot{
+		{Code: "E001", Format: "tiny", Path: "validation"},
+		{Code: "E002", Format: "tiny", Path: "not_found"},
+	}, "v1.0.0")
+
+	next := Snapshot{
+		{Code: "E001", Format: "tiny", Path: "validation"},
+		{Code: "E003", Format: "tiny", Path: "unauthorized"},
+	}
+	if problems := lock.Check(next); len(problems) != 0 {
+		t.Fatalf("Check() = %v; want no problems", problems)
+	}
+	lock.Update(next, "v1.1.0")
+
+	want := []LockEntry{
+		{Code: "E001", Format: "tiny", Path: "validation", FirstSeen: "v1.0.0"},
+		{Code: "E002", Format: "tiny", Path: "not_found", FirstSeen: "v1.0.0", Retired: true},
+		{Code: "E003", Format: "tiny", Path: "unauthorized", FirstSeen: "v1.1.0"},
+	}
+	if len(lock.Entries) != len(want) {
+		t.Fatalf("Entries = %v; want %v", lock.Entries, want)
+	}
+	for i := range want {
+		if lock.Entries[i] != want[i] {
+			t.Errorf("Entries[%d] = %+v; want %+v", i, lock.Entries[i], want[i])
+		}
+	}
+
+	reuse := Snapshot{{Code: "E002", Format: "tiny", Path: "gone"}}
+	problems := lock.Check(reuse)
+	if len(problems) != 1 || problems[0].String() != `tiny: gone: reuses retired code E002 of "not_found" (first seen v1.0.0)` {
+		t.Errorf("Check() = %v; want one reuse problem", problems)
+	}
+}
+
+func TestLockCheckRename(t *testing.T) {
+	lock := &Lock{}
+	lock.Update(Snapshot{{Code: "E001", Format: "tiny", Path: "validation", Description: "old"}}, "v1.0.0")
+
+	renamed := Snapshot{{Code: "E001", Format: "tiny", Path: "invalid_input", Description: "new"}}
+	problems := lock.Check(renamed)
+	want := `tiny: invalid_input: changes path of code E001 from "validation" (first seen v1.0.0); retire it and allocate a new code`
+	if len(problems) != 1 || problems[0].String() != want {
+		t.Errorf("Check() = %v; want %q", problems, want)
+	}
+
+	// Update leaves the locked entry unchanged even if called without Check
+	lock.Update(renamed, "v1.1.0")
+	if e, _ := lock.Lookup("E001"); e.Path != "validation" || e.Description != "old" || e.Retired {
+		t.Errorf("Lookup(E001) = %+v; want path validation, description old", e)
+	}
+}
+
+func TestLockUpdateRetiredStatus(t *testing.T) {
+	setTrees(t, func() {
+		TinyCodeValues = []TinyErrorInfo{
+			{Value: 0, Name: "unknown"},
+			{Value: 1, Name: "validation"},
+			{Value: 2, Name: "gone", Lifecycle: Lifecycle{Status: StatusRetired}},
+		}
+	})
+	lock := &Lock{Entries: []LockEntry{
+		{Code: "E001", Format: "tiny", Path: "validation", FirstSeen: "v1.0.0"},
+	}}
+	snapshot := TakeSnapshot()
+	if e := snapshot[2]; e.Code != "E002" || !e.Retired || snapshot[1].Retired {
+		t.Fatalf("TakeSnapshot() = %+v; want only E002 retired", snapshot)
+	}
+	lock.Update(snapshot, "v1.1.0")
+	if e, _ := lock.Lookup("E002"); !e.Retired || e.FirstSeen != "v1.1.0" {
+		t.Errorf("Lookup(E002) = %+v; want retired entry first seen in v1.1.0", e)
+	}
+
+	// Retiring a locked code in the catalog retires it in the lock
+	ReplaceTrees(func() { TinyCodeValues[1].Lifecycle.Status = StatusRetired })
+	lock.Update(TakeSnapshot(), "v1.2.0")
+	if e, _ := lock.Lookup("E001"); !e.Retired || e.FirstSeen != "v1.0.0" {
+		t.Errorf("Lookup(E001) = %+v; want retired entry first seen in v1.0.0", e)
+	}
+}
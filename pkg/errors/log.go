@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+)
+
+// codeAttrs returns the log attributes of a code: the encoded code, its
+// format and path and one attribute per layout field. Fields hold the
+// catalog names of known codes and the raw values of unknown ones.
+func codeAttrs(code ErrorType) []slog.Attr {
+	encoded, err := EncodeChecked(code)
+	if err != nil {
+		return []slog.Attr{slog.String("code", "invalid"), slog.String("error", err.Error())}
+	}
+	attrs := []slog.Attr{slog.String("code", encoded)}
+	f, ok := LookupFormat(code.GetType())
+	if !ok {
+		return attrs
+	}
+	attrs = append(attrs, slog.String("format", f.Name))
+
+	p, known := Lookup(code)
+	if known {
+		attrs = append(attrs, slog.String("path", code.String()))
+	}
+	if f.Layout == nil {
+		return attrs
+	}
+	values, err := f.Layout.Decode(encoded)
+	if err != nil {
+		return attrs
+	}
+	for i, field := range f.Layout.Fields {
+		key := naming.FieldKey(field.Name)
+		if known {
+			attrs = append(attrs, slog.String(key, p.Fields[field.Name]))
+		} else {
+			attrs = append(attrs, slog.Uint64(key, uint64(values[i])))
+		}
+	}
+	return attrs
+}
+
+// LogValue logs the error as a group of its code attributes, message and
+// cause
+func (e *Error) LogValue() slog.Value {
+	var attrs []slog.Attr
+	if e.Code != nil {
+		attrs = codeAttrs(e.Code)
+	}
+	if e.Message != "" {
+		attrs = append(attrs, slog.String("message", e.Message))
+	}
+	if e.Cause != nil {
+		attrs = append(attrs, slog.String("cause", e.Cause.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogHandler is a slog.Handler middleware that expands codes and errors
+// carrying a code, also when wrapped, into attribute groups with the
+// severity and owner of the code's catalog entry
+type LogHandler struct {
+	next slog.Handler
+}
+
+// NewLogHandler returns a handler expanding coded errors before passing
+// records on to next
+func NewLogHandler(next slog.Handler) *LogHandler {
+	return &LogHandler{next: next}
+}
+
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	expanded := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		expanded.AddAttrs(expandAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, expanded)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expandAttr(a)
+	}
+	return &LogHandler{next: h.next.WithAttrs(expanded)}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{next: h.next.WithGroup(name)}
+}
+
+// expandAttr replaces codes and coded errors in a, descending into groups
+func expandAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		expanded := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			expanded[i] = expandAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny, slog.KindLogValuer:
+		if attrs, ok := codedAttrs(a.Value.Any()); ok {
+			return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+		}
+	}
+	return a
+}
+
+// codedAttrs returns the attributes of a code or of an error carrying one,
+// followed by the catalog metadata of the code
+func codedAttrs(v any) ([]slog.Attr, bool) {
+	var code ErrorType
+	var attrs []slog.Attr
+	switch v := v.(type) {
+	case ErrorType:
+		code, attrs = v, codeAttrs(v)
+	case *Error:
+		if v == nil || v.Code == nil {
+			return nil, false
+		}
+		code, attrs = v.Code, v.LogValue().Group()
+	case error:
+		// Bare codes wrapped with %w are found by their own Error method
+		c, ok := CodeOf(v)
+		if !ok && !stderrors.As(v, &c) {
+			return nil, false
+		}
+		code, attrs = c, append(codeAttrs(c), slog.String("error", v.Error()))
+	default:
+		return nil, false
+	}
+
+	if p, ok := Lookup(code); ok {
+		attrs = append(attrs, slog.String("severity", string(p.Metadata.Severity.OrDefault())))
+		if p.Metadata.Owner != "" {
+			attrs = append(attrs, slog.String("owner", p.Metadata.Owner))
+		}
+	}
+	return attrs, true
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+// useMetadataTree installs a tree whose app has an owner and whose error
+// type overrides the severity
+func useMetadataTree(t *testing.T) AppComponentErrorCode {
+	setTrees(t, func() {
+		CodeTree = []AppInfo{
+			{
+				Value:    1,
+				Name:     "billing",
+				Metadata: Metadata{Owner: "billing-team", Severity: SeverityWarning},
+				Components: []ComponentInfo{
+					{Value: 2, Name: "invoices", SubComponents: []SubComponentInfo{
+						{Value: 3, Name: "pdf", ErrorTypes: []ErrorInfo{
+							{Value: 4, Name: "render_failed", Metadata: Metadata{Severity: SeverityCritical}},
+						}},
+					}},
+				},
+			},
+		}
+	})
+	return AppComponentErrorCode{App: 1, Component: 2, SubComponent: 3, ErrType: 4}
+}
+
+// logJSON logs one record with the given attributes and returns it decoded
+func logJSON(t *testing.T, wrap func(slog.Handler) slog.Handler, args ...any) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	slog.New(wrap(slog.NewJSONHandler(&buf, nil))).Error("failed", args...)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log output %q: %v", buf.String(), err)
+	}
+	return record
+}
+
+func plain(h slog.Handler) slog.Handler { return h }
+
+func withCodes(h slog.Handler) slog.Handler { return NewLogHandler(h) }
+
+func TestInheritMetadata(t *testing.T) {
+	code := useMetadataTree(t)
+	p, ok := Lookup(code)
+	if !ok {
+		t.Fatal("Lookup() found no entry")
+	}
+	if want := (Metadata{Severity: SeverityCritical, Owner: "billing-team"}); p.Metadata != want {
+		t.Errorf("Metadata = %+v; want %+v", p.Metadata, want)
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	setTrees(t, func() {
+		TinyCodeValues = []TinyErrorInfo{
+			{Value: 0, Name: "unknown", Metadata: Metadata{Severity: SeverityInfo}},
+			{Value: 1, Name: "paging", Metadata: Metadata{Severity: "page"}},
+		}
+	})
+	problems := validateTiny()
+	if want := `tiny: paging: unknown severity "page"`; len(problems) != 1 || problems[0].String() != want {
+		t.Errorf("validateTiny() = %v; want [%s]", problems, want)
+	}
+}
+
+func TestCodeLogValue(t *testing.T) {
+	code := useMetadataTree(t)
+
+	record := logJSON(t, plain, "code", code)
+	want := map[string]any{
+		"code":          code.Encode(),
+		"format":        "app_component",
+		"path":          "billing.invoices.pdf.render_failed",
+		"app":           "billing",
+		"component":     "invoices",
+		"sub_component": "pdf",
+		"error_type":    "render_failed",
+	}
+	if got := fmt.Sprint(record["code"]); got != fmt.Sprint(want) {
+		t.Errorf("code = %s; want %s", got, fmt.Sprint(want))
+	}
+
+	record = logJSON(t, plain, "code", AppComponentErrorCode{App: 1, Component: 9})
+	group := record["code"].(map[string]any)
+	if _, ok := group["path"]; ok || group["component"] != float64(9) {
+		t.Errorf("unknown code = %v; want raw field values and no path", group)
+	}
+
+	record = logJSON(t, plain, "err", Wrap(fmt.Errorf("timeout"), code, "render invoice"))
+	group = record["err"].(map[string]any)
+	if group["code"] != code.Encode() || group["message"] != "render invoice" || group["cause"] != "timeout" {
+		t.Errorf("err = %v", group)
+	}
+	if _, ok := group["severity"]; ok {
+		t.Error("LogValue should not include catalog metadata")
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	code := useMetadataTree(t)
+	err := New(code, "render invoice")
+
+	tests := []struct {
+		name  string
+		value any
+		want  map[string]any
+	}{
+		{"code", code, map[string]any{"path": "billing.invoices.pdf.render_failed", "severity": "critical", "owner": "billing-team"}},
+		{"error", err, map[string]any{"message": "render invoice", "severity": "critical", "owner": "billing-team"}},
+		{"wrapped error", fmt.Errorf("job 7: %w", err), map[string]any{"error": "job 7: " + err.Error(), "severity": "critical"}},
+		{"wrapped code", fmt.Errorf("job 7: %w", code), map[string]any{"path": "billing.invoices.pdf.render_failed", "error": "job 7: " + code.Error(), "owner": "billing-team"}},
+		{"unknown code", SimpleCode{Class: 200}, map[string]any{"class": float64(200)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, ok := logJSON(t, withCodes, "err", tt.value)["err"].(map[string]any)
+			if !ok {
+				t.Fatalf("err was not expanded into a group")
+			}
+			for k, v := range tt.want {
+				if group[k] != v {
+					t.Errorf("%s = %v; want %v", k, group[k], v)
+				}
+			}
+		})
+	}
+
+	t.Run("plain values", func(t *testing.T) {
+		record := logJSON(t, withCodes, "err", fmt.Errorf("plain"), "n", 1)
+		if record["err"] != "plain" || record["n"] != float64(1) {
+			t.Errorf("record = %v; want plain values untouched", record)
+		}
+	})
+
+	t.Run("groups and logger attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))).With("first", err)
+		logger.WithGroup("req").Error("failed", slog.Group("job", "err", err))
+
+		var record struct {
+			First map[string]any
+			Req   struct{ Job struct{ Err map[string]any } }
+		}
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		if record.First["owner"] != "billing-team" || record.Req.Job.Err["owner"] != "billing-team" {
+			t.Errorf("log output %s; want both errors expanded", buf.String())
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package errors
+
+// Lookup returns the catalog entry of a code. The entry may share its maps
+// and slices with the catalog index and must not be modified.
+func Lookup(code ErrorType) (Permutation, bool) {
+	f, ok := LookupFormat(code.GetType())
+	if !ok {
+		return Permutation{}, false
+	}
+	if f.Lookup != nil {
+		return f.Lookup(code)
+	}
+	encoded, err := EncodeChecked(code)
+	if err != nil {
+		return Permutation{}, false
+	}
+
+	for _, p := range f.Prototype.GetPermutations() {
+		if p.Code == encoded {
+			return p, true
+		}
+	}
+	return Permutation{}, false
+}
@@ -0,0 +1,62 @@
+package errors
+
+import "fmt"
+
+// Severity is the operational severity of an error type
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+// Valid reports whether s is a known severity. The empty severity means
+// error.
+func (s Severity) Valid() bool {
+	switch s {
+	case "", SeverityInfo, SeverityWarning, SeverityError, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// OrDefault returns the severity, treating the empty severity as error
+func (s Severity) OrDefault() Severity {
+	if s == "" {
+		return SeverityError
+	}
+	return s
+}
+
+// Metadata holds the operational metadata of a catalog entry. It is
+// embedded in the info structs of every tree level.
+type Metadata struct {
+	Severity Severity // Empty means error
+	Owner    string   // Team responsible for the entry
+}
+
+// inheritMetadata returns the metadata of a code from the metadata of its
+// tree nodes, given from leaf to root. Each field is taken from the
+// nearest node setting it.
+func inheritMetadata(levels ...Metadata) Metadata {
+	var result Metadata
+	for _, m := range levels {
+		if result.Severity == "" {
+			result.Severity = m.Severity
+		}
+		if result.Owner == "" {
+			result.Owner = m.Owner
+		}
+	}
+	return result
+}
+
+// checkMetadata reports problems with the metadata of an entry
+func checkMetadata(m Metadata) []string {
+	if !m.Severity.Valid() {
+		return []string{fmt.Sprintf("unknown severity %q", m.Severity)}
+	}
+	return nil
+}
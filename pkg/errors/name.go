@@ -0,0 +1,159 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+)
+
+// Sentinel errors wrapped by NameError, for use with errors.Is
+var (
+	ErrUnknownName   = stderrors.New("unknown name")
+	ErrAmbiguousName = stderrors.New("ambiguous name")
+)
+
+// NameError describes why a dotted name could not be parsed into a code
+type NameError struct {
+	Name     string
+	Format   string   // Format the name was parsed as, empty if no single format applies
+	Segment  int      // Index of the failing segment, -1 if the segment count is wrong
+	Level    string   // Tree level of the failing segment, e.g. "sub component"
+	Expected []string // Valid names at the failing segment, the expected segments, the matching formats or the segments of each format
+	Err      error    // ErrUnknownName or ErrAmbiguousName
+}
+
+func (e *NameError) Error() string {
+	switch {
+	case e.Err == ErrAmbiguousName:
+		return fmt.Sprintf("parse name %q: %v, matches formats %s; qualify it as %s:%s",
+			e.Name, e.Err, strings.Join(e.Expected, ", "), e.Expected[0], e.Name)
+	case e.Segment < 0 && e.Format == "":
+		return fmt.Sprintf("parse name %q: no format has names of %s, expected one of: %s",
+			e.Name, segmentCount(len(strings.Split(e.Name, "."))), strings.Join(e.Expected, ", "))
+	case e.Segment < 0:
+		return fmt.Sprintf("parse %s name %q: expected %s %s",
+			e.Format, e.Name, segmentCount(len(e.Expected)), strings.Join(e.Expected, "."))
+	}
+	segments := strings.Split(e.Name, ".")
+	msg := fmt.Sprintf("parse %s name %q: unknown %s %q", e.Format, e.Name, e.Level, segments[e.Segment])
+	if e.Segment > 0 {
+		msg += fmt.Sprintf(" in %s", strings.Join(segments[:e.Segment], "."))
+	}
+	if len(e.Expected) == 0 {
+		return msg + ", no names defined at this level"
+	}
+	return msg + ", expected one of: " + strings.Join(e.Expected, ", ")
+}
+
+// segmentCount returns "1 segment" or "n segments"
+func segmentCount(n int) string {
+	if n == 1 {
+		return "1 segment"
+	}
+	return fmt.Sprintf("%d segments", n)
+}
+
+func (e *NameError) Unwrap() error {
+	return e.Err
+}
+
+// nameParser splits a dotted name into one segment per layout field
+type nameParser struct {
+	format   string
+	layout   Layout
+	name     string
+	segments []string
+}
+
+func newNameParser(format string, layout Layout, name string) (*nameParser, error) {
+	p := &nameParser{format: format, layout: layout, name: name, segments: strings.Split(name, ".")}
+	if len(p.segments) != len(layout.Fields) {
+		var expected []string
+		for _, f := range layout.Fields {
+			expected = append(expected, naming.FieldKey(f.Name))
+		}
+		return nil, &NameError{Name: name, Format: format, Segment: -1, Expected: expected, Err: ErrUnknownName}
+	}
+	return p, nil
+}
+
+// findName returns the item named by segment i of the parsed name
+func findName[T any](p *nameParser, i int, items []T, name func(T) string) (T, error) {
+	var names []string
+	for _, item := range items {
+		if name(item) == p.segments[i] {
+			return item, nil
+		}
+		names = append(names, name(item))
+	}
+
+	var zero T
+	return zero, &NameError{
+		Name:     p.name,
+		Format:   p.format,
+		Segment:  i,
+		Level:    fieldWords(p.layout.Fields[i].Name),
+		Expected: names,
+		Err:      ErrUnknownName,
+	}
+}
+
+// ParseName returns the code with the given dotted name, as produced by its
+// String method, and its catalog entry. The name may be qualified with a
+// format name, e.g. "simple511:http.not_found", and has to be if codes of
+// several formats have that name.
+func ParseName(name string) (ErrorType, Permutation, error) {
+	var formats []Format
+	if format, rest, ok := strings.Cut(name, ":"); ok {
+		f, ok := LookupFormatName(format)
+		if !ok {
+			return nil, Permutation{}, fmt.Errorf("parse name %q: unknown format %q", name, format)
+		}
+		formats, name = []Format{f}, rest
+	} else {
+		formats = Formats()
+	}
+
+	var matches, layouts []string
+	var code ErrorType
+	var bestErr error
+	bestSegment := -2
+	for _, f := range formats {
+		if f.ParseName == nil {
+			continue
+		}
+		c, err := f.ParseName(name)
+		if err == nil {
+			matches = append(matches, f.Name)
+			code = c
+			continue
+		}
+		// Report the error of the format that matched the most segments
+		var nameErr *NameError
+		if !stderrors.As(err, &nameErr) {
+			return nil, Permutation{}, err
+		}
+		if nameErr.Segment > bestSegment {
+			bestErr, bestSegment = err, nameErr.Segment
+		}
+		if nameErr.Segment < 0 {
+			layouts = append(layouts, f.Name+":"+strings.Join(nameErr.Expected, "."))
+		}
+	}
+
+	switch {
+	case len(matches) > 1:
+		return nil, Permutation{}, &NameError{Name: name, Segment: -1, Expected: matches, Err: ErrAmbiguousName}
+	case len(matches) == 1:
+		p, _ := Lookup(code)
+		return code, p, nil
+	case bestSegment < 0 && len(layouts) > 1:
+		// No format accepts the segment count
+		return nil, Permutation{}, &NameError{Name: name, Segment: -1, Expected: layouts, Err: ErrUnknownName}
+	case bestErr != nil:
+		return nil, Permutation{}, bestErr
+	}
+	return nil, Permutation{}, fmt.Errorf("parse name %q: no format supports names", name)
+}
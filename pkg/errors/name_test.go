@@ -0,0 +1,107 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestParseFormatNames(t *testing.T) {
+	for _, f := range Formats() {
+		for _, p := range f.Prototype.GetPermutations() {
+			code, err := f.Decode(p.Code)
+			if err != nil {
+				t.Fatalf("Decode(%s) returned error: %v", p.Code, err)
+			}
+			parsed, err := f.ParseName(code.String())
+			if err != nil {
+				t.Errorf("%s: ParseName(%q) returned error: %v", f.Name, code, err)
+				continue
+			}
+			if parsed.Encode() != p.Code {
+				t.Errorf("%s: ParseName(%q) = %s; want %s", f.Name, code, parsed.Encode(), p.Code)
+			}
+		}
+	}
+}
+
+func TestParseAppComponentName(t *testing.T) {
+	code, perm, err := ParseAppComponentName("backend.handler.users.validation_error")
+	if err != nil {
+		t.Fatalf("ParseAppComponentName() returned error: %v", err)
+	}
+	if code != BackendHandlerUsersValidationError || perm.Code != "EA0MTXD" {
+		t.Errorf("ParseAppComponentName() = %v, %s", code, perm.Code)
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantCode string
+		wantErr  string
+	}{
+		{name: "backend.handler.users.validation_error", wantCode: "EA0MTXD"},
+		{name: "not_found", wantCode: "E002"},
+		{name: "api.validation_error", wantCode: "E10075"},
+		{name: "simple511:max.max", wantCode: "E31EKF"},
+		{
+			name:    "max.max",
+			wantErr: `parse name "max.max": ambiguous name, matches formats simple, simple511; qualify it as simple:max.max`,
+		},
+		{
+			name:    "backend.handlr.users.validation_error",
+			wantErr: `parse app_component name "backend.handlr.users.validation_error": unknown component "handlr" in backend, expected one of: handler, job`,
+		},
+		{
+			name:    "api.nope",
+			wantErr: `parse simple name "api.nope": unknown error type "nope" in api, expected one of: unknown, validation_error, authorization_error`,
+		},
+		{
+			name:    "app_component:backend.handler",
+			wantErr: `parse app_component name "backend.handler": expected 4 segments app.component.sub_component.error_type`,
+		},
+		{
+			name:    "tiny:not_found.x",
+			wantErr: `parse tiny name "not_found.x": expected 1 segment error_type`,
+		},
+		{
+			name: "backend.handler.users",
+			wantErr: `parse name "backend.handler.users": no format has names of 3 segments, expected one of: ` +
+				`tiny:error_type, simple:class.error_type, simple511:class.error_type, app_component:app.component.sub_component.error_type`,
+		},
+		{
+			name:    "huge:api.unknown",
+			wantErr: `parse name "huge:api.unknown": unknown format "huge"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, perm, err := ParseName(tt.name)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ParseName() error = %v; want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseName() returned error: %v", err)
+			}
+			if code.Encode() != tt.wantCode || perm.Code != tt.wantCode {
+				t.Errorf("ParseName() = %s (entry %s); want %s", code.Encode(), perm.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNameErrorSentinels(t *testing.T) {
+	_, _, err := ParseName("api.nope")
+	var nameErr *NameError
+	if !stderrors.Is(err, ErrUnknownName) || !stderrors.As(err, &nameErr) || nameErr.Segment != 1 {
+		t.Errorf("ParseName() error = %#v; want NameError at segment 1 wrapping ErrUnknownName", err)
+	}
+
+	if _, _, err := ParseName("unknown.unknown"); !stderrors.Is(err, ErrAmbiguousName) {
+		t.Errorf("ParseName() error = %v; want ErrAmbiguousName", err)
+	}
+}
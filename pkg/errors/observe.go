@@ -0,0 +1,56 @@
+package errors
+
+import (
+	stderrors "errors"
+	"sync/atomic"
+)
+
+// Event is the occurrence of a coded error passed to the observer
+type Event int
+
+const (
+	EventCreated  Event = iota // The error was created by New, Newf or Wrap
+	EventReported              // The error was passed to Report
+)
+
+func (e Event) String() string {
+	switch e {
+	case EventCreated:
+		return "created"
+	case EventReported:
+		return "reported"
+	}
+	return "unknown"
+}
+
+var observer atomic.Pointer[func(Event, *Error)]
+
+// SetObserver registers a function called when a coded error is created or
+// reported, e.g. to count errors by code. A nil observer disables the
+// calls, which is the default. The observer must be safe for concurrent
+// use.
+func SetObserver(o func(event Event, err *Error)) {
+	if o == nil {
+		observer.Store(nil)
+		return
+	}
+	observer.Store(&o)
+}
+
+// Report notifies the observer that err was reported, e.g. logged or
+// returned to a client, and returns err. Errors without an *Error in their
+// chain are not observed.
+func Report(err error) error {
+	var e *Error
+	if stderrors.As(err, &e) {
+		observe(EventReported, e)
+	}
+	return err
+}
+
+// observe calls the observer if one is set and err has a code
+func observe(event Event, err *Error) {
+	if o := observer.Load(); o != nil && err.Code != nil {
+		(*o)(event, err)
+	}
+}
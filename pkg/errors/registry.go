@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Format describes an error code format that can be registered so that
+// Decode, docgen and other tooling pick it up
+type Format struct {
+	Name      string    // Short identifier, e.g. "app_component"
+	Prototype ErrorType // Zero value used for docs and permutations
+	Layout    *Layout   // Optional bit layout of layout-based formats
+	Decode    func(code string) (ErrorType, error)
+	Validate  func() []Problem                         // Optional catalog checks, used by Validate
+	ParseName func(name string) (ErrorType, error)     // Optional, used by ParseName
+	Lookup    func(code ErrorType) (Permutation, bool) // Optional index used by Lookup instead of scanning the permutations
+}
+
+// Type returns the code type of the format
+func (f Format) Type() CodeType {
+	return f.Prototype.GetType()
+}
+
+// Length returns the length of an encoded code of this format
+func (f Format) Length() int {
+	if f.Layout != nil {
+		return f.Layout.Length()
+	}
+	return len(f.Prototype.Encode())
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[CodeType]Format{}
+)
+
+// Register adds a format for the given code type. It fails if the type or
+// name is already taken or does not fit into the single type character.
+func Register(t CodeType, f Format) error {
+	if t >= 36 {
+		return fmt.Errorf("code type %d does not fit into one base36 character", t)
+	}
+	if f.Name == "" || f.Prototype == nil || f.Decode == nil {
+		return fmt.Errorf("format for code type %d must have a name, prototype and decoder", t)
+	}
+	if f.Type() != t {
+		return fmt.Errorf("format %q reports code type %d, registered as %d", f.Name, f.Type(), t)
+	}
+	if f.Layout != nil {
+		if f.Layout.Type != t {
+			return fmt.Errorf("format %q layout has code type %d, registered as %d", f.Name, f.Layout.Type, t)
+		}
+		if err := f.Layout.validate(); err != nil {
+			return fmt.Errorf("format %q: %w", f.Name, err)
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if existing, ok := registry[t]; ok {
+		return fmt.Errorf("code type %d already registered by format %q", t, existing.Name)
+	}
+	for _, existing := range registry {
+		if existing.Name == f.Name {
+			return fmt.Errorf("format name %q already registered for code type %d", f.Name, existing.Type())
+		}
+	}
+	registry[t] = f
+	return nil
+}
+
+// MustRegister is like Register but panics on error
+func MustRegister(t CodeType, f Format) {
+	if err := Register(t, f); err != nil {
+		panic(err)
+	}
+}
+
+// LookupFormat returns the format registered for the given code type
+func LookupFormat(t CodeType) (Format, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	f, ok := registry[t]
+	return f, ok
+}
+
+// LookupFormatCode returns the format registered for the type character of
+// an encoded code, the base36 character following the E prefix. The rest of
+// the code is not checked.
+func LookupFormatCode(code string) (Format, bool) {
+	if len(code) < 2 || !isBase36Char(code[1]) {
+		return Format{}, false
+	}
+	t, _ := fromBase36(code[1:2])
+	return LookupFormat(CodeType(t))
+}
+
+// LookupFormatName returns the format registered under the given name
+func LookupFormatName(name string) (Format, bool) {
+	for _, f := range Formats() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// Formats returns all registered formats ordered by code type
+func Formats() []Format {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	formats := make([]Format, 0, len(registry))
+	for _, f := range registry {
+		formats = append(formats, f)
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		return formats[i].Type() < formats[j].Type()
+	})
+	return formats
+}
@@ -0,0 +1,154 @@
+package errors
+
+import "testing"
+
+type testCode struct{ TinyCode }
+
+func (testCode) GetType() CodeType {
+	return CodeType(20)
+}
+
+func TestRegister(t *testing.T) {
+	decode := func(code string) (ErrorType, error) { return testCode{}, nil }
+
+	if err := Register(CodeTypeTiny, Format{Name: "other", Prototype: TinyCode{}, Decode: decode}); err == nil {
+		t.Error("Register with duplicate code type should fail")
+	}
+	if err := Register(20, Format{Name: "tiny", Prototype: testCode{}, Decode: decode}); err == nil {
+		t.Error("Register with duplicate name should fail")
+	}
+	if err := Register(21, Format{Name: "mismatch", Prototype: testCode{}, Decode: decode}); err == nil {
+		t.Error("Register with mismatched code type should fail")
+	}
+	if err := Register(36, Format{Name: "too_big", Prototype: testCode{}, Decode: decode}); err == nil {
+		t.Error("Register with code type beyond one base36 character should fail")
+	}
+}
+
+// regionCode is a custom format with a region and an error type, registered
+// by TestRegisterCustomFormat
+type regionCode struct {
+	Region  uint32
+	ErrType uint32
+}
+
+const codeTypeRegion CodeType = 22
+
+var regionLayout = Layout{
+	Type:  codeTypeRegion,
+	Width: 2,
+	Fields: []LayoutField{
+		{Name: "Region", Bits: 4, Description: "Identifies the region"},
+		{Name: "ErrorType", Bits: 6, Description: "Identifies the specific error"},
+	},
+}
+
+func (c regionCode) Encode() string {
+	return regionLayout.EncodeMasked(c.Region, c.ErrType)
+}
+
+func (c regionCode) String() string {
+	if c == (regionCode{Region: 1, ErrType: 2}) {
+		return "eu.timeout"
+	}
+	return "invalid"
+}
+
+func (regionCode) GetFieldInfo() []FieldInfo {
+	return regionLayout.FieldInfo("1: eu", "2: timeout")
+}
+
+func (regionCode) GetPermutations() []Permutation {
+	return []Permutation{{
+		Type:        codeTypeRegion,
+		Code:        regionCode{Region: 1, ErrType: 2}.Encode(),
+		Fields:      map[string]string{"Region": "eu", "ErrorType": "timeout", "Description": "Timeout in the EU region"},
+		TableFields: []string{"eu.timeout", "Timeout in the EU region"},
+	}}
+}
+
+func (regionCode) GetType() CodeType         { return codeTypeRegion }
+func (regionCode) GetPrefix() string         { return "E" }
+func (regionCode) GetDocSection() DocSection { return DocSection{Title: "Region Format"} }
+
+func TestRegisterCustomFormat(t *testing.T) {
+	err := Register(codeTypeRegion, Format{
+		Name:      "region",
+		Prototype: regionCode{},
+		Layout:    &regionLayout,
+		Decode: func(code string) (ErrorType, error) {
+			values, err := regionLayout.Decode(code)
+			if err != nil {
+				return nil, err
+			}
+			return regionCode{Region: values[0], ErrType: values[1]}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, codeTypeRegion)
+	})
+
+	code := regionCode{Region: 1, ErrType: 2}
+	encoded, err := EncodeChecked(code)
+	if err != nil || encoded != "EM1U" {
+		t.Fatalf("EncodeChecked() = %q, %v; want EM1U", encoded, err)
+	}
+	decoded, err := Decode(encoded)
+	if err != nil || decoded != code {
+		t.Errorf("Decode(%q) = %v, %v; want %v", encoded, decoded, err, code)
+	}
+
+	p, ok := Lookup(decoded)
+	if !ok || p.Fields["Description"] != "Timeout in the EU region" {
+		t.Errorf("Lookup(%v) = %+v, %v", decoded, p, ok)
+	}
+	if _, ok := Lookup(regionCode{Region: 2, ErrType: 2}); ok {
+		t.Error("Lookup of a code missing from the permutations should fail")
+	}
+	if f, ok := LookupFormatName("region"); !ok || f.Type() != codeTypeRegion {
+		t.Errorf("LookupFormatName(region) = %v, %v", f.Name, ok)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	want := []CodeType{CodeTypeTiny, CodeTypeSimple, CodeTypeSimple511, CodeTypeAppComponent}
+	formats := Formats()
+	if len(formats) != len(want) {
+		t.Fatalf("Formats() returned %d formats; want %d", len(formats), len(want))
+	}
+	for i, f := range formats {
+		if f.Type() != want[i] {
+			t.Errorf("Formats()[%d] type = %d; want %d", i, f.Type(), want[i])
+		}
+	}
+
+	if f, ok := LookupFormatName("app_component"); !ok || f.Type() != CodeTypeAppComponent {
+		t.Errorf("LookupFormatName(app_component) = %v, %v", f.Name, ok)
+	}
+}
+
+func TestLookupFormatCode(t *testing.T) {
+	tests := []struct {
+		code   string
+		format string
+		ok     bool
+	}{
+		{"E10075", "simple", true},
+		{"EA0MTXD", "app_component", true},
+		{"EA", "app_component", true},
+		{"EZ000", "", false},
+		{"Ea0MTXD", "", false},
+		{"E", "", false},
+	}
+	for _, tt := range tests {
+		f, ok := LookupFormatCode(tt.code)
+		if ok != tt.ok || f.Name != tt.format {
+			t.Errorf("LookupFormatCode(%q) = %q, %v; want %q, %v", tt.code, f.Name, ok, tt.format, tt.ok)
+		}
+	}
+}
@@ -24,10 +24,14 @@ type DocSection struct {
 }
 
 type Permutation struct {
-	Type        CodeType
-	Code        string
-	Fields      map[string]string
-	TableFields []string // Fields in order for table display
+	Type         CodeType
+	Code         string
+	Fields       map[string]string
+	TableFields  []string     // Fields in order for table display
+	HTTPStatus   int          // Status of the error type, 0 if not configured
+	Localization Localization // Translated texts of the error type
+	Lifecycle    Lifecycle    // Inherited from all levels of the code's tree
+	Metadata     Metadata     // Each field inherited from the nearest level setting it
 }
 
 // Interface that all error types must implement
@@ -40,3 +44,9 @@ type ErrorType interface {
 	GetPrefix() string
 	GetDocSection() DocSection
 }
+
+// CheckedEncoder is implemented by error types that can report values which
+// do not fit into the format instead of masking them or panicking
+type CheckedEncoder interface {
+	EncodeChecked() (string, error)
+}
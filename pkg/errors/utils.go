@@ -7,11 +7,12 @@ import (
 
 const base36Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
-// toBase36 converts a number to base36 string with fixed width
-func toBase36(num uint32, width int) string {
-	maxValue := uint32(math.Pow(36, float64(width)))
-	if num >= maxValue {
-		panic(fmt.Sprintf("number %d too large for width %d (max %d)", num, width, maxValue-1))
+// toBase36 converts a number to a base36 string with fixed width, returning
+// an ErrOverflow error if the number does not fit into width characters
+func toBase36(num uint32, width int) (string, error) {
+	maxValue := uint64(math.Pow(36, float64(width)))
+	if uint64(num) >= maxValue {
+		return "", fmt.Errorf("%w: number %d too large for width %d (max %d)", ErrOverflow, num, width, maxValue-1)
 	}
 
 	result := make([]byte, width)
@@ -19,13 +20,19 @@ func toBase36(num uint32, width int) string {
 		result[i] = base36Chars[num%36]
 		num /= 36
 	}
-	return string(result)
+	return string(result), nil
 }
 
-// fromBase36 converts a base36 string to number
-func fromBase36(s string) uint32 {
+// isBase36Char reports whether c is a valid upper-case base36 digit
+func isBase36Char(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z')
+}
+
+// fromBase36 converts a base36 string to a number, returning a *DecodeError
+// on empty input, invalid characters or uint32 overflow
+func fromBase36(s string) (uint32, error) {
 	if s == "" {
-		panic("empty string")
+		return 0, &DecodeError{Code: s, Pos: -1, Err: ErrInvalidLength}
 	}
 
 	var result uint32
@@ -38,9 +45,12 @@ func fromBase36(s string) uint32 {
 		case c >= 'A' && c <= 'Z':
 			val = uint32(c - 'A' + 10)
 		default:
-			panic(fmt.Sprintf("invalid base36 character: %c", c))
+			return 0, &DecodeError{Code: s, Pos: i, Err: ErrInvalidChar}
+		}
+		if result > (math.MaxUint32-val)/36 {
+			return 0, &DecodeError{Code: s, Pos: i, Err: ErrOverflow}
 		}
 		result = result*36 + val
 	}
-	return result
+	return result, nil
 }
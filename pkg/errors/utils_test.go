@@ -1,6 +1,9 @@
 package errors
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestBase36(t *testing.T) {
 	tests := []struct {
@@ -26,15 +29,15 @@ func TestBase36(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Test encoding
-			encoded := toBase36(tt.value, tt.width)
-			if encoded != tt.encoded {
+			encoded, err := toBase36(tt.value, tt.width)
+			if err != nil || encoded != tt.encoded {
 				t.Errorf("toBase36(%d, %d) = %s; want %s",
 					tt.value, tt.width, encoded, tt.encoded)
 			}
 
 			// Test decoding
-			decoded := fromBase36(tt.encoded)
-			if decoded != tt.value {
+			decoded, err := fromBase36(tt.encoded)
+			if err != nil || decoded != tt.value {
 				t.Errorf("fromBase36(%s) = %d; want %d",
 					tt.encoded, decoded, tt.value)
 			}
@@ -56,12 +59,12 @@ func TestBase36Invalid(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("fromBase36(%s) should panic", tt.input)
-				}
-			}()
-			fromBase36(tt.input)
+			if _, err := fromBase36(tt.input); err == nil {
+				t.Errorf("fromBase36(%s) should fail", tt.input)
+			}
 		})
 	}
+	if _, err := toBase36(36, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("toBase36(36, 1) error = %v; want ErrOverflow", err)
+	}
 }
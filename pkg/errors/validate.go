@@ -0,0 +1,205 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thommeo/error-code-design/internal/naming"
+)
+
+// Problem is a catalog inconsistency found by Validate
+type Problem struct {
+	Format  string // Name of the registered format
+	Path    string // Dotted path of the tree node, empty for the tree root
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return fmt.Sprintf("%s: %s", p.Format, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.Format, p.Path, p.Message)
+}
+
+// Validate checks the trees of all registered formats and returns every
+// problem found
+func Validate() []Problem {
+	var problems []Problem
+	for _, f := range Formats() {
+		if f.Validate != nil {
+			problems = append(problems, f.Validate()...)
+		}
+	}
+	return problems
+}
+
+// levelEntry is a name and value of a node at one level of a tree
+type levelEntry struct {
+	Name      string
+	Value     uint32
+	Lifecycle Lifecycle
+	Metadata  Metadata
+}
+
+// levelChecker validates the entries below one tree node against one
+// layout field
+type levelChecker struct {
+	format   string
+	layout   Layout
+	problems []Problem
+}
+
+// newLevelChecker returns a checker for the registered format of type t
+func newLevelChecker(t CodeType, layout Layout) *levelChecker {
+	f, _ := LookupFormat(t)
+	return &levelChecker{format: f.Name, layout: layout}
+}
+
+func (c *levelChecker) add(path, format string, args ...any) {
+	c.problems = append(c.problems, Problem{
+		Format:  c.format,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// check reports duplicate values and names, values exceeding the field
+// width and, if requireUnknown is set, a missing unknown(0) entry
+func (c *levelChecker) check(path string, field int, entries []levelEntry, requireUnknown bool) {
+	kind := fieldWords(c.layout.Fields[field].Name)
+	maxValue := c.layout.MaxValue(field)
+	byValue := map[uint32]string{}
+	byName := map[string]uint32{}
+	hasUnknown := false
+
+	for _, e := range entries {
+		entryPath := joinPath(path, e.Name)
+		if e.Name == "" {
+			c.add(path, "%s %d has an empty name", kind, e.Value)
+		}
+		if e.Value > maxValue {
+			c.add(entryPath, "%s value %d exceeds maximum of %d (%d bits)", kind, e.Value, maxValue, c.layout.Fields[field].Bits)
+		}
+		if other, ok := byValue[e.Value]; ok {
+			c.add(entryPath, "duplicate %s value %d, also used by %q", kind, e.Value, other)
+		} else {
+			byValue[e.Value] = e.Name
+		}
+		if other, ok := byName[e.Name]; ok && e.Name != "" {
+			c.add(entryPath, "duplicate %s name, also used by value %d", kind, other)
+		} else {
+			byName[e.Name] = e.Value
+		}
+		for _, msg := range checkLifecycle(e.Lifecycle) {
+			c.add(entryPath, "%s", msg)
+		}
+		for _, msg := range checkMetadata(e.Metadata) {
+			c.add(entryPath, "%s", msg)
+		}
+		if e.Value == 0 && e.Name == "unknown" {
+			hasUnknown = true
+		}
+	}
+
+	if requireUnknown && !hasUnknown {
+		c.add(path, "missing unknown(0) %s", kind)
+	}
+}
+
+// checkErrorType reports problems with the HTTP status and the translated
+// texts of an error type
+func (c *levelChecker) checkErrorType(path string, httpStatus int, l Localization) {
+	if httpStatus != 0 && (httpStatus < 400 || httpStatus > 599) {
+		c.add(path, "HTTP status %d is not an error status", httpStatus)
+	}
+	for _, msg := range checkLocalization(l) {
+		c.add(path, "%s", msg)
+	}
+}
+
+// fieldWords turns a field name like "SubComponent" into "sub component"
+func fieldWords(name string) string {
+	return strings.ReplaceAll(naming.FieldKey(name), "_", " ")
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func validateTiny() []Problem {
+	c := newLevelChecker(CodeTypeTiny, tinyLayout)
+	var entries []levelEntry
+	for _, e := range currentTrees().tiny {
+		entries = append(entries, levelEntry{e.Name, uint32(e.Value), e.Lifecycle, e.Metadata})
+		c.checkErrorType(e.Name, e.HTTPStatus, e.Localization)
+	}
+	c.check("", 0, entries, true)
+	return c.problems
+}
+
+func validateSimple() []Problem {
+	c := newLevelChecker(CodeTypeSimple, simpleLayout)
+	var classes []levelEntry
+	for _, class := range currentTrees().simple {
+		classes = append(classes, levelEntry{class.Name, uint32(class.Value), class.Lifecycle, class.Metadata})
+
+		var errTypes []levelEntry
+		for _, e := range class.ErrorTypes {
+			errTypes = append(errTypes, levelEntry{e.Name, uint32(e.Value), e.Lifecycle, e.Metadata})
+			c.checkErrorType(joinPath(class.Name, e.Name), e.HTTPStatus, e.Localization)
+		}
+		c.check(class.Name, 1, errTypes, true)
+	}
+	c.check("", 0, classes, false)
+	return c.problems
+}
+
+func validateSimple511() []Problem {
+	c := newLevelChecker(CodeTypeSimple511, simple511Layout)
+	var classes []levelEntry
+	for _, class := range currentTrees().simple511 {
+		classes = append(classes, levelEntry{class.Name, uint32(class.Value), class.Lifecycle, class.Metadata})
+
+		var errTypes []levelEntry
+		for _, e := range class.ErrorTypes {
+			errTypes = append(errTypes, levelEntry{e.Name, uint32(e.Value), e.Lifecycle, e.Metadata})
+			c.checkErrorType(joinPath(class.Name, e.Name), e.HTTPStatus, e.Localization)
+		}
+		c.check(class.Name, 1, errTypes, true)
+	}
+	c.check("", 0, classes, false)
+	return c.problems
+}
+
+func validateAppComponent() []Problem {
+	c := newLevelChecker(CodeTypeAppComponent, appComponentLayout)
+	var apps []levelEntry
+	for _, app := range currentTrees().app {
+		apps = append(apps, levelEntry{app.Name, uint32(app.Value), app.Lifecycle, app.Metadata})
+
+		var comps []levelEntry
+		for _, comp := range app.Components {
+			comps = append(comps, levelEntry{comp.Name, uint32(comp.Value), comp.Lifecycle, comp.Metadata})
+			compPath := joinPath(app.Name, comp.Name)
+
+			var subComps []levelEntry
+			for _, subComp := range comp.SubComponents {
+				subComps = append(subComps, levelEntry{subComp.Name, uint32(subComp.Value), subComp.Lifecycle, subComp.Metadata})
+
+				var errTypes []levelEntry
+				for _, e := range subComp.ErrorTypes {
+					errTypes = append(errTypes, levelEntry{e.Name, uint32(e.Value), e.Lifecycle, e.Metadata})
+					c.checkErrorType(joinPath(joinPath(compPath, subComp.Name), e.Name), e.HTTPStatus, e.Localization)
+				}
+				c.check(joinPath(compPath, subComp.Name), 3, errTypes, false)
+			}
+			c.check(compPath, 2, subComps, true)
+		}
+		c.check(app.Name, 1, comps, false)
+	}
+	c.check("", 0, apps, false)
+	return c.problems
+}
@@ -0,0 +1,77 @@
+package errors
+
+import "testing"
+
+func TestValidateBuiltinCatalog(t *testing.T) {
+	for _, p := range Validate() {
+		t.Errorf("unexpected problem: %s", p)
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	setTrees(t, func() {
+		CodeTree = []AppInfo{
+			{
+				Value: 16,
+				Name:  "backend",
+				Components: []ComponentInfo{
+					{
+						Value: 1,
+						Name:  "handler",
+						SubComponents: []SubComponentInfo{
+							{Value: 1, Name: "users", ErrorTypes: []ErrorInfo{
+								{Value: 1, Name: "validation_error"},
+								{Value: 1, Name: "authorization_error"},
+								{Value: 2, Name: "validation_error", HTTPStatus: 200},
+							}},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	want := []string{
+		`app_component: backend.handler.users.validation_error: HTTP status 200 is not an error status`,
+		`app_component: backend.handler.users.authorization_error: duplicate error type value 1, also used by "validation_error"`,
+		`app_component: backend.handler.users.validation_error: duplicate error type name, also used by value 1`,
+		`app_component: backend.handler: missing unknown(0) sub component`,
+		`app_component: backend: app value 16 exceeds maximum of 15 (4 bits)`,
+	}
+
+	problems := validateAppComponent()
+	if len(problems) != len(want) {
+		t.Fatalf("validateAppComponent() returned %d problems; want %d: %v", len(problems), len(want), problems)
+	}
+	for i := range want {
+		if problems[i].String() != want[i] {
+			t.Errorf("problem %d = %s; want %s", i, problems[i], want[i])
+		}
+	}
+}
+
+func TestValidateUnknown(t *testing.T) {
+	// unknown(0) is required among the tiny codes and the error types of
+	// each class, but not among the classes
+	setTrees(t, func() {
+		TinyCodeValues = []TinyErrorInfo{{Value: 1, Name: "validation"}}
+		SimpleCodeTree = []SimpleClassInfo{
+			{Value: 1, Name: "api", ErrorTypes: []SimpleErrorInfo{{Value: 1, Name: "validation_error"}}},
+			{Value: 2, Name: "jobs", ErrorTypes: []SimpleErrorInfo{{Value: 0, Name: "unknown"}}},
+		}
+	})
+
+	want := []string{
+		`tiny: missing unknown(0) error type`,
+		`simple: api: missing unknown(0) error type`,
+	}
+	problems := append(validateTiny(), validateSimple()...)
+	if len(problems) != len(want) {
+		t.Fatalf("problems = %v; want %v", problems, want)
+	}
+	for i := range want {
+		if problems[i].String() != want[i] {
+			t.Errorf("problem %d = %s; want %s", i, problems[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Checks the SDK against testdata/codes.json, written by sdkgen from the Go
+// implementation. Run with Node.js 22.7 or later:
+//
+//	node --experimental-transform-types --test error-codes.test.ts
+
+import assert from "node:assert/strict";
+import { readFileSync } from "node:fs";
+import { test } from "node:test";
+
+import { ErrorCodeError, decode, encodeLayout, layouts } from "./error-codes.ts";
+
+interface FixtureCode {
+  format: string;
+  code: string;
+  values: Record<string, number>;
+  path?: string;
+}
+
+interface FixtureInvalid {
+  code: string;
+  kind: string;
+  pos: number;
+}
+
+interface Fixture {
+  valid: FixtureCode[];
+  invalid: FixtureInvalid[];
+}
+
+const fixture: Fixture = JSON.parse(readFileSync(new URL("./testdata/codes.json", import.meta.url), "utf8"));
+
+test("decodes the codes encoded by Go", () => {
+  for (const c of fixture.valid) {
+    const decoded = decode(c.code);
+    assert.equal(decoded.format, c.format, c.code);
+    assert.deepEqual(decoded.values, c.values, c.code);
+    assert.equal(decoded.entry?.path, c.path, c.code);
+  }
+});
+
+test("encodes the codes encoded by Go", () => {
+  for (const c of fixture.valid) {
+    const layout = layouts[c.format];
+    assert.equal(encodeLayout(layout, layout.fields.map((f) => c.values[f.name])), c.code);
+  }
+});
+
+test("rejects the codes rejected by Go", () => {
+  for (const c of fixture.invalid) {
+    assert.throws(
+      () => decode(c.code),
+      (err: unknown) => err instanceof ErrorCodeError && err.kind === c.kind && err.pos === c.pos,
+      c.code,
+    );
+  }
+});
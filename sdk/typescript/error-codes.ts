@@ -0,0 +1,1035 @@
+// Code generated by sdkgen. DO NOT EDIT.
+//
+// Error code catalog and encode/decode functions, bit-compatible with the
+// Go implementation in github.com/thommeo/error-code-design/pkg/errors.
+
+export interface LayoutField {
+  name: string;
+  bits: number;
+}
+
+export interface Layout {
+  type: number;
+  width: number;
+  fields: LayoutField[];
+}
+
+export interface CatalogEntry {
+  code: string;
+  path: string;
+  description: string;
+  fields: Record<string, string>;
+  descriptions?: Record<string, string>;
+  messages?: Record<string, string>;
+  status?: "deprecated" | "retired";
+  deprecatedSince?: string;
+  replacement?: string;
+}
+
+export type ErrorCodeErrorKind = "invalid_length" | "invalid_char" | "unknown_type" | "overflow";
+
+export class ErrorCodeError extends Error {
+  constructor(
+    readonly kind: ErrorCodeErrorKind,
+    readonly code: string,
+    readonly pos: number = -1,
+  ) {
+    super(pos >= 0 ? `decode "${code}": ${kind} at position ${pos}` : `"${code}": ${kind}`);
+    this.name = "ErrorCodeError";
+  }
+}
+
+const BASE36 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ";
+
+export function toBase36(num: number, width: number): string {
+  if (!Number.isInteger(num) || num < 0 || num >= 36 ** width) {
+    throw new ErrorCodeError("overflow", String(num));
+  }
+  let result = "";
+  for (let i = 0; i < width; i++) {
+    result = BASE36[num % 36] + result;
+    num = Math.floor(num / 36);
+  }
+  return result;
+}
+
+export function fromBase36(s: string, code: string = s, offset: number = 0): number {
+  let result = 0;
+  for (let i = 0; i < s.length; i++) {
+    const val = BASE36.indexOf(s[i]);
+    if (val < 0) {
+      throw new ErrorCodeError("invalid_char", code, offset + i);
+    }
+    result = result * 36 + val;
+  }
+  return result;
+}
+
+function bits(layout: Layout): number {
+  return layout.fields.reduce((sum, f) => sum + f.bits, 0);
+}
+
+function capacity(layout: Layout): number {
+  return Math.min(2 ** bits(layout), 36 ** layout.width);
+}
+
+function maxValue(layout: Layout, i: number): number {
+  let max = 2 ** layout.fields[i].bits - 1;
+  if (i === 0) {
+    // The most significant field is also limited by the data width
+    const shift = bits(layout) - layout.fields[0].bits;
+    max = Math.min(max, Math.floor((capacity(layout) - 1) / 2 ** shift));
+  }
+  return max;
+}
+
+export function encodeLayout(layout: Layout, values: number[]): string {
+  if (values.length !== layout.fields.length) {
+    throw new Error(`layout has ${layout.fields.length} fields, got ${values.length} values`);
+  }
+  let packed = 0;
+  layout.fields.forEach((f, i) => {
+    const v = values[i];
+    if (!Number.isInteger(v) || v < 0 || v > maxValue(layout, i)) {
+      throw new ErrorCodeError("overflow", `${f.name}=${v}`);
+    }
+    packed = packed * 2 ** f.bits + v;
+  });
+  return "E" + toBase36(layout.type, 1) + toBase36(packed, layout.width);
+}
+
+export function decodeLayout(layout: Layout, code: string): number[] {
+  if (code.length !== layout.width + 2) {
+    throw new ErrorCodeError("invalid_length", code);
+  }
+  if (code[0] !== "E") {
+    throw new ErrorCodeError("invalid_char", code, 0);
+  }
+  if (fromBase36(code.slice(1, 2), code, 1) !== layout.type) {
+    throw new ErrorCodeError("unknown_type", code, 1);
+  }
+  let packed = fromBase36(code.slice(2), code, 2);
+  if (packed >= capacity(layout)) {
+    throw new ErrorCodeError("overflow", code);
+  }
+  const values = new Array<number>(layout.fields.length);
+  for (let i = layout.fields.length - 1; i >= 0; i--) {
+    const size = 2 ** layout.fields[i].bits;
+    values[i] = packed % size;
+    packed = Math.floor(packed / size);
+  }
+  return values;
+}
+
+// Tiny Format
+
+export const TinyLayout: Layout = {
+  "type": 0,
+  "width": 2,
+  "fields": [
+    {
+      "name": "error_type",
+      "bits": 11
+    }
+  ]
+};
+
+export interface TinyCode {
+  error_type: number;
+}
+
+export type TinyCodeString =
+  | "E000"
+  | "E001"
+  | "E002"
+  | "E003"
+  | "E004"
+  | "E0ZZ";
+
+export type TinyPath =
+  | "unknown"
+  | "validation"
+  | "not_found"
+  | "unauthorized"
+  | "bad_request"
+  | "max";
+
+export const TinyCatalog: Record<TinyCodeString, CatalogEntry> = {
+  "E000": {
+    "code": "E000",
+    "path": "unknown",
+    "description": "Unknown error",
+    "fields": {
+      "error_type": "unknown"
+    }
+  },
+  "E001": {
+    "code": "E001",
+    "path": "validation",
+    "description": "Validation error",
+    "fields": {
+      "error_type": "validation"
+    }
+  },
+  "E002": {
+    "code": "E002",
+    "path": "not_found",
+    "description": "Resource not found",
+    "fields": {
+      "error_type": "not_found"
+    }
+  },
+  "E003": {
+    "code": "E003",
+    "path": "unauthorized",
+    "description": "Unauthorized access",
+    "fields": {
+      "error_type": "unauthorized"
+    }
+  },
+  "E004": {
+    "code": "E004",
+    "path": "bad_request",
+    "description": "Bad request",
+    "fields": {
+      "error_type": "bad_request"
+    }
+  },
+  "E0ZZ": {
+    "code": "E0ZZ",
+    "path": "max",
+    "description": "Maximum error value (ZZ)",
+    "fields": {
+      "error_type": "max"
+    }
+  }
+};
+
+export function encodeTinyCode(c: TinyCode): string {
+  return encodeLayout(TinyLayout, [c.error_type]);
+}
+
+export function decodeTinyCode(code: string): TinyCode {
+  const v = decodeLayout(TinyLayout, code);
+  return { error_type: v[0] };
+}
+
+// Simple Format
+
+export const SimpleLayout: Layout = {
+  "type": 1,
+  "width": 4,
+  "fields": [
+    {
+      "name": "class",
+      "bits": 8
+    },
+    {
+      "name": "error_type",
+      "bits": 8
+    }
+  ]
+};
+
+export interface SimpleCode {
+  class: number;
+  error_type: number;
+}
+
+export type SimpleCodeString =
+  | "E10000"
+  | "E10074"
+  | "E10075"
+  | "E10076"
+  | "E100E8"
+  | "E100E9"
+  | "E100EA"
+  | "E11EDC"
+  | "E11EKF";
+
+export type SimplePath =
+  | "unknown.unknown"
+  | "api.unknown"
+  | "api.validation_error"
+  | "api.authorization_error"
+  | "jobs.unknown"
+  | "jobs.database_query"
+  | "jobs.timeout"
+  | "max.unknown"
+  | "max.max";
+
+export const SimpleCatalog: Record<SimpleCodeString, CatalogEntry> = {
+  "E10000": {
+    "code": "E10000",
+    "path": "unknown.unknown",
+    "description": "Unknown API error",
+    "fields": {
+      "class": "unknown",
+      "error_type": "unknown"
+    }
+  },
+  "E10074": {
+    "code": "E10074",
+    "path": "api.unknown",
+    "description": "Unknown API error",
+    "fields": {
+      "class": "api",
+      "error_type": "unknown"
+    }
+  },
+  "E10075": {
+    "code": "E10075",
+    "path": "api.validation_error",
+    "description": "API validation error",
+    "fields": {
+      "class": "api",
+      "error_type": "validation_error"
+    }
+  },
+  "E10076": {
+    "code": "E10076",
+    "path": "api.authorization_error",
+    "description": "API authorization error",
+    "fields": {
+      "class": "api",
+      "error_type": "authorization_error"
+    }
+  },
+  "E100E8": {
+    "code": "E100E8",
+    "path": "jobs.unknown",
+    "description": "Unknown job error",
+    "fields": {
+      "class": "jobs",
+      "error_type": "unknown"
+    }
+  },
+  "E100E9": {
+    "code": "E100E9",
+    "path": "jobs.database_query",
+    "description": "Database query error in job",
+    "fields": {
+      "class": "jobs",
+      "error_type": "database_query"
+    }
+  },
+  "E100EA": {
+    "code": "E100EA",
+    "path": "jobs.timeout",
+    "description": "Job execution timeout",
+    "fields": {
+      "class": "jobs",
+      "error_type": "timeout"
+    }
+  },
+  "E11EDC": {
+    "code": "E11EDC",
+    "path": "max.unknown",
+    "description": "Unknown max class error",
+    "fields": {
+      "class": "max",
+      "error_type": "unknown"
+    }
+  },
+  "E11EKF": {
+    "code": "E11EKF",
+    "path": "max.max",
+    "description": "Max error type number",
+    "fields": {
+      "class": "max",
+      "error_type": "max"
+    }
+  }
+};
+
+export function encodeSimpleCode(c: SimpleCode): string {
+  return encodeLayout(SimpleLayout, [c.class, c.error_type]);
+}
+
+export function decodeSimpleCode(code: string): SimpleCode {
+  const v = decodeLayout(SimpleLayout, code);
+  return { class: v[0], error_type: v[1] };
+}
+
+// Simple 5-11 Format
+
+export const Simple511Layout: Layout = {
+  "type": 3,
+  "width": 4,
+  "fields": [
+    {
+      "name": "class",
+      "bits": 5
+    },
+    {
+      "name": "error_type",
+      "bits": 11
+    }
+  ]
+};
+
+export interface Simple511Code {
+  class: number;
+  error_type: number;
+}
+
+export type Simple511CodeString =
+  | "E30000"
+  | "E301KW"
+  | "E301KX"
+  | "E301KY"
+  | "E301KZ"
+  | "E301L0"
+  | "E31CZK"
+  | "E31EKF";
+
+export type Simple511Path =
+  | "unknown.unknown"
+  | "http.unknown"
+  | "http.bad_request"
+  | "http.unauthorized"
+  | "http.forbidden"
+  | "http.not_found"
+  | "max.unknown"
+  | "max.max";
+
+export const Simple511Catalog: Record<Simple511CodeString, CatalogEntry> = {
+  "E30000": {
+    "code": "E30000",
+    "path": "unknown.unknown",
+    "description": "Unknown error",
+    "fields": {
+      "class": "unknown",
+      "error_type": "unknown"
+    }
+  },
+  "E301KW": {
+    "code": "E301KW",
+    "path": "http.unknown",
+    "description": "Unknown HTTP error",
+    "fields": {
+      "class": "http",
+      "error_type": "unknown"
+    }
+  },
+  "E301KX": {
+    "code": "E301KX",
+    "path": "http.bad_request",
+    "description": "Bad request error (400)",
+    "fields": {
+      "class": "http",
+      "error_type": "bad_request"
+    }
+  },
+  "E301KY": {
+    "code": "E301KY",
+    "path": "http.unauthorized",
+    "description": "Unauthorized error (401)",
+    "fields": {
+      "class": "http",
+      "error_type": "unauthorized"
+    }
+  },
+  "E301KZ": {
+    "code": "E301KZ",
+    "path": "http.forbidden",
+    "description": "Forbidden error (403)",
+    "fields": {
+      "class": "http",
+      "error_type": "forbidden"
+    }
+  },
+  "E301L0": {
+    "code": "E301L0",
+    "path": "http.not_found",
+    "description": "Not found error (404)",
+    "fields": {
+      "class": "http",
+      "error_type": "not_found"
+    }
+  },
+  "E31CZK": {
+    "code": "E31CZK",
+    "path": "max.unknown",
+    "description": "Unknown max class error",
+    "fields": {
+      "class": "max",
+      "error_type": "unknown"
+    }
+  },
+  "E31EKF": {
+    "code": "E31EKF",
+    "path": "max.max",
+    "description": "Maximum error type value",
+    "fields": {
+      "class": "max",
+      "error_type": "max"
+    }
+  }
+};
+
+export function encodeSimple511Code(c: Simple511Code): string {
+  return encodeLayout(Simple511Layout, [c.class, c.error_type]);
+}
+
+export function decodeSimple511Code(code: string): Simple511Code {
+  const v = decodeLayout(Simple511Layout, code);
+  return { class: v[0], error_type: v[1] };
+}
+
+// App Component Format
+
+export const AppComponentLayout: Layout = {
+  "type": 10,
+  "width": 5,
+  "fields": [
+    {
+      "name": "app",
+      "bits": 4
+    },
+    {
+      "name": "component",
+      "bits": 6
+    },
+    {
+      "name": "sub_component",
+      "bits": 6
+    },
+    {
+      "name": "error_type",
+      "bits": 8
+    }
+  ]
+};
+
+export interface AppComponentCode {
+  app: number;
+  component: number;
+  sub_component: number;
+  error_type: number;
+}
+
+export type AppComponentCodeString =
+  | "EA0MTQ8"
+  | "EA0MTXD"
+  | "EA0MTXE"
+  | "EA0MU4H"
+  | "EA0MU4I"
+  | "EA0MUBL"
+  | "EA0MUBM"
+  | "EA0N6DC"
+  | "EA0N6KH"
+  | "EA0N6KI"
+  | "EA0N6KJ"
+  | "EA0N6RL"
+  | "EA0N6RM"
+  | "EA0N6RN"
+  | "EA19ATC"
+  | "EA19B0H"
+  | "EA19B0I"
+  | "EA19B7L"
+  | "EA19B7M"
+  | "EA19NGG"
+  | "EA19NNL"
+  | "EA19NNM"
+  | "EA19NUP"
+  | "EA19NUQ"
+  | "EA1A03K"
+  | "EA1A0AP"
+  | "EA1A0AQ"
+  | "EA1A0AR"
+  | "EA1A0HT"
+  | "EA1A0HU"
+  | "EA9Z8QO"
+  | "EA9ZLDR";
+
+export type AppComponentPath =
+  | "backend.handler.unknown.unknown"
+  | "backend.handler.users.validation_error"
+  | "backend.handler.users.authorization_error"
+  | "backend.handler.records.validation_error"
+  | "backend.handler.records.authorization_error"
+  | "backend.handler.analytics.validation_error"
+  | "backend.handler.analytics.authorization_error"
+  | "backend.job.unknown.unknown"
+  | "backend.job.sync.database_error"
+  | "backend.job.sync.external_api_error"
+  | "backend.job.sync.timeout"
+  | "backend.job.analytics.database_error"
+  | "backend.job.analytics.external_api_error"
+  | "backend.job.analytics.timeout"
+  | "frontend.ui.unknown.unknown"
+  | "frontend.ui.forms.validation_error"
+  | "frontend.ui.forms.submission_error"
+  | "frontend.ui.routing.not_found"
+  | "frontend.ui.routing.unauthorized"
+  | "frontend.state.unknown.unknown"
+  | "frontend.state.store.update_failed"
+  | "frontend.state.store.invalid_action"
+  | "frontend.state.persistence.storage_error"
+  | "frontend.state.persistence.sync_error"
+  | "frontend.api.unknown.unknown"
+  | "frontend.api.request.network_error"
+  | "frontend.api.request.timeout"
+  | "frontend.api.request.invalid_response"
+  | "frontend.api.cache.cache_miss"
+  | "frontend.api.cache.cache_invalid"
+  | "max.max_component.unknown.unknown"
+  | "max.max_component.max_subcomponent.max_error";
+
+export const AppComponentCatalog: Record<AppComponentCodeString, CatalogEntry> = {
+  "EA0MTQ8": {
+    "code": "EA0MTQ8",
+    "path": "backend.handler.unknown.unknown",
+    "description": "Unknown handler error",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "unknown",
+      "sub_component": "unknown"
+    }
+  },
+  "EA0MTXD": {
+    "code": "EA0MTXD",
+    "path": "backend.handler.users.validation_error",
+    "description": "Input validation failed for user operation",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "validation_error",
+      "sub_component": "users"
+    }
+  },
+  "EA0MTXE": {
+    "code": "EA0MTXE",
+    "path": "backend.handler.users.authorization_error",
+    "description": "User lacks required permissions for operation",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "authorization_error",
+      "sub_component": "users"
+    }
+  },
+  "EA0MU4H": {
+    "code": "EA0MU4H",
+    "path": "backend.handler.records.validation_error",
+    "description": "Input validation failed for record operation",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "validation_error",
+      "sub_component": "records"
+    }
+  },
+  "EA0MU4I": {
+    "code": "EA0MU4I",
+    "path": "backend.handler.records.authorization_error",
+    "description": "User lacks required permissions for record operation",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "authorization_error",
+      "sub_component": "records"
+    }
+  },
+  "EA0MUBL": {
+    "code": "EA0MUBL",
+    "path": "backend.handler.analytics.validation_error",
+    "description": "Input validation failed for analytics operation",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "validation_error",
+      "sub_component": "analytics"
+    }
+  },
+  "EA0MUBM": {
+    "code": "EA0MUBM",
+    "path": "backend.handler.analytics.authorization_error",
+    "description": "User lacks required permissions for analytics operation",
+    "fields": {
+      "app": "backend",
+      "component": "handler",
+      "error_type": "authorization_error",
+      "sub_component": "analytics"
+    }
+  },
+  "EA0N6DC": {
+    "code": "EA0N6DC",
+    "path": "backend.job.unknown.unknown",
+    "description": "Unknown job error",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "unknown",
+      "sub_component": "unknown"
+    }
+  },
+  "EA0N6KH": {
+    "code": "EA0N6KH",
+    "path": "backend.job.sync.database_error",
+    "description": "Database operation failed during sync",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "database_error",
+      "sub_component": "sync"
+    }
+  },
+  "EA0N6KI": {
+    "code": "EA0N6KI",
+    "path": "backend.job.sync.external_api_error",
+    "description": "External API call failed during sync",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "external_api_error",
+      "sub_component": "sync"
+    }
+  },
+  "EA0N6KJ": {
+    "code": "EA0N6KJ",
+    "path": "backend.job.sync.timeout",
+    "description": "Operation timed out during sync",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "timeout",
+      "sub_component": "sync"
+    }
+  },
+  "EA0N6RL": {
+    "code": "EA0N6RL",
+    "path": "backend.job.analytics.database_error",
+    "description": "Database operation failed during analytics processing",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "database_error",
+      "sub_component": "analytics"
+    }
+  },
+  "EA0N6RM": {
+    "code": "EA0N6RM",
+    "path": "backend.job.analytics.external_api_error",
+    "description": "External API call failed during analytics processing",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "external_api_error",
+      "sub_component": "analytics"
+    }
+  },
+  "EA0N6RN": {
+    "code": "EA0N6RN",
+    "path": "backend.job.analytics.timeout",
+    "description": "Operation timed out during analytics processing",
+    "fields": {
+      "app": "backend",
+      "component": "job",
+      "error_type": "timeout",
+      "sub_component": "analytics"
+    }
+  },
+  "EA19ATC": {
+    "code": "EA19ATC",
+    "path": "frontend.ui.unknown.unknown",
+    "description": "Unknown UI error",
+    "fields": {
+      "app": "frontend",
+      "component": "ui",
+      "error_type": "unknown",
+      "sub_component": "unknown"
+    }
+  },
+  "EA19B0H": {
+    "code": "EA19B0H",
+    "path": "frontend.ui.forms.validation_error",
+    "description": "Form validation failed",
+    "fields": {
+      "app": "frontend",
+      "component": "ui",
+      "error_type": "validation_error",
+      "sub_component": "forms"
+    }
+  },
+  "EA19B0I": {
+    "code": "EA19B0I",
+    "path": "frontend.ui.forms.submission_error",
+    "description": "Form submission failed",
+    "fields": {
+      "app": "frontend",
+      "component": "ui",
+      "error_type": "submission_error",
+      "sub_component": "forms"
+    }
+  },
+  "EA19B7L": {
+    "code": "EA19B7L",
+    "path": "frontend.ui.routing.not_found",
+    "description": "Route not found",
+    "fields": {
+      "app": "frontend",
+      "component": "ui",
+      "error_type": "not_found",
+      "sub_component": "routing"
+    }
+  },
+  "EA19B7M": {
+    "code": "EA19B7M",
+    "path": "frontend.ui.routing.unauthorized",
+    "description": "Route access unauthorized",
+    "fields": {
+      "app": "frontend",
+      "component": "ui",
+      "error_type": "unauthorized",
+      "sub_component": "routing"
+    }
+  },
+  "EA19NGG": {
+    "code": "EA19NGG",
+    "path": "frontend.state.unknown.unknown",
+    "description": "Unknown state error",
+    "fields": {
+      "app": "frontend",
+      "component": "state",
+      "error_type": "unknown",
+      "sub_component": "unknown"
+    }
+  },
+  "EA19NNL": {
+    "code": "EA19NNL",
+    "path": "frontend.state.store.update_failed",
+    "description": "State update operation failed",
+    "fields": {
+      "app": "frontend",
+      "component": "state",
+      "error_type": "update_failed",
+      "sub_component": "store"
+    }
+  },
+  "EA19NNM": {
+    "code": "EA19NNM",
+    "path": "frontend.state.store.invalid_action",
+    "description": "Invalid state action dispatched",
+    "fields": {
+      "app": "frontend",
+      "component": "state",
+      "error_type": "invalid_action",
+      "sub_component": "store"
+    }
+  },
+  "EA19NUP": {
+    "code": "EA19NUP",
+    "path": "frontend.state.persistence.storage_error",
+    "description": "Local storage operation failed",
+    "fields": {
+      "app": "frontend",
+      "component": "state",
+      "error_type": "storage_error",
+      "sub_component": "persistence"
+    }
+  },
+  "EA19NUQ": {
+    "code": "EA19NUQ",
+    "path": "frontend.state.persistence.sync_error",
+    "description": "State synchronization failed",
+    "fields": {
+      "app": "frontend",
+      "component": "state",
+      "error_type": "sync_error",
+      "sub_component": "persistence"
+    }
+  },
+  "EA1A03K": {
+    "code": "EA1A03K",
+    "path": "frontend.api.unknown.unknown",
+    "description": "Unknown API error",
+    "fields": {
+      "app": "frontend",
+      "component": "api",
+      "error_type": "unknown",
+      "sub_component": "unknown"
+    }
+  },
+  "EA1A0AP": {
+    "code": "EA1A0AP",
+    "path": "frontend.api.request.network_error",
+    "description": "Network request failed",
+    "fields": {
+      "app": "frontend",
+      "component": "api",
+      "error_type": "network_error",
+      "sub_component": "request"
+    }
+  },
+  "EA1A0AQ": {
+    "code": "EA1A0AQ",
+    "path": "frontend.api.request.timeout",
+    "description": "Request timed out",
+    "fields": {
+      "app": "frontend",
+      "component": "api",
+      "error_type": "timeout",
+      "sub_component": "request"
+    }
+  },
+  "EA1A0AR": {
+    "code": "EA1A0AR",
+    "path": "frontend.api.request.invalid_response",
+    "description": "Invalid response received",
+    "fields": {
+      "app": "frontend",
+      "component": "api",
+      "error_type": "invalid_response",
+      "sub_component": "request"
+    }
+  },
+  "EA1A0HT": {
+    "code": "EA1A0HT",
+    "path": "frontend.api.cache.cache_miss",
+    "description": "Cache miss error",
+    "fields": {
+      "app": "frontend",
+      "component": "api",
+      "error_type": "cache_miss",
+      "sub_component": "cache"
+    }
+  },
+  "EA1A0HU": {
+    "code": "EA1A0HU",
+    "path": "frontend.api.cache.cache_invalid",
+    "description": "Cache invalidation error",
+    "fields": {
+      "app": "frontend",
+      "component": "api",
+      "error_type": "cache_invalid",
+      "sub_component": "cache"
+    }
+  },
+  "EA9Z8QO": {
+    "code": "EA9Z8QO",
+    "path": "max.max_component.unknown.unknown",
+    "description": "Unknown max component error",
+    "fields": {
+      "app": "max",
+      "component": "max_component",
+      "error_type": "unknown",
+      "sub_component": "unknown"
+    }
+  },
+  "EA9ZLDR": {
+    "code": "EA9ZLDR",
+    "path": "max.max_component.max_subcomponent.max_error",
+    "description": "Maximum possible error code value",
+    "fields": {
+      "app": "max",
+      "component": "max_component",
+      "error_type": "max_error",
+      "sub_component": "max_subcomponent"
+    }
+  }
+};
+
+export function encodeAppComponentCode(c: AppComponentCode): string {
+  return encodeLayout(AppComponentLayout, [c.app, c.component, c.sub_component, c.error_type]);
+}
+
+export function decodeAppComponentCode(code: string): AppComponentCode {
+  const v = decodeLayout(AppComponentLayout, code);
+  return { app: v[0], component: v[1], sub_component: v[2], error_type: v[3] };
+}
+
+export type ErrorCodeString = TinyCodeString | SimpleCodeString | Simple511CodeString | AppComponentCodeString;
+
+export const layouts: Record<string, Layout> = {
+  tiny: TinyLayout,
+  simple: SimpleLayout,
+  simple511: Simple511Layout,
+  app_component: AppComponentLayout,
+};
+
+export const catalog: Record<string, CatalogEntry> = {
+  ...TinyCatalog,
+  ...SimpleCatalog,
+  ...Simple511Catalog,
+  ...AppComponentCatalog,
+};
+
+export interface DecodedCode {
+  format: string;
+  code: string;
+  values: Record<string, number>;
+  entry?: CatalogEntry;
+}
+
+// decode reads the type character following the E prefix and decodes the
+// code with the matching format
+export function decode(code: string): DecodedCode {
+  if (code.length < 2) {
+    throw new ErrorCodeError("invalid_length", code);
+  }
+  if (code[0] !== "E") {
+    throw new ErrorCodeError("invalid_char", code, 0);
+  }
+  const type = fromBase36(code.slice(1, 2), code, 1);
+  for (const [format, layout] of Object.entries(layouts)) {
+    if (layout.type !== type) {
+      continue;
+    }
+    const values = decodeLayout(layout, code);
+    const named: Record<string, number> = {};
+    layout.fields.forEach((f, i) => (named[f.name] = values[i]));
+    return { format, code, values: named, entry: catalog[code] };
+  }
+  throw new ErrorCodeError("unknown_type", code, 1);
+}
+
+export const DEFAULT_LOCALE = "en";
+
+// localeFallbacks returns the locales tried for a locale, from the most to
+// the least specific, ending with the default locale: de-AT, de, en
+export function localeFallbacks(locale: string): string[] {
+  const chain: string[] = [];
+  let l = locale.trim().replace(/_/g, "-");
+  while (l !== "") {
+    chain.push(l);
+    const i = l.lastIndexOf("-");
+    if (i < 0) {
+      break;
+    }
+    l = l.slice(0, i);
+  }
+  if (chain.length === 0 || chain[chain.length - 1].toLowerCase() !== DEFAULT_LOCALE) {
+    chain.push(DEFAULT_LOCALE);
+  }
+  return chain;
+}
+
+function localized(texts: Record<string, string> | undefined, locale: string): string | undefined {
+  if (!texts) {
+    return undefined;
+  }
+  for (const l of localeFallbacks(locale)) {
+    const key = Object.keys(texts).find((k) => k.toLowerCase() === l.toLowerCase());
+    if (key !== undefined) {
+      return texts[key];
+    }
+  }
+  return undefined;
+}
+
+// description returns the description of a code in the given locale,
+// falling back to English
+export function description(code: string, locale: string = DEFAULT_LOCALE): string | undefined {
+  const entry = catalog[code];
+  return entry && (localized(entry.descriptions, locale) ?? entry.description);
+}
+
+// message returns the end-user message of a code in the given locale,
+// falling back to the localized description
+export function message(code: string, locale: string = DEFAULT_LOCALE): string | undefined {
+  const entry = catalog[code];
+  return entry && (localized(entry.messages, locale) ?? description(code, locale));
+}